@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// autoOffCmd powers the speakers off after a period of no playback
+var autoOffCmd = &cobra.Command{
+	Use:   "auto-off",
+	Short: "Turn the speakers off when they have been idle for a while",
+	Long: `Watch the speakers and turn them off when nothing has been playing for the
+given duration. Any playback resets the idle timer.
+
+TV, optical and the other wired inputs can't report if anything is playing,
+so by default the speakers are left on while one of those is selected.`,
+	Args: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		after, _ := cmd.Flags().GetDuration("after")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		keepOnTV, _ := cmd.Flags().GetBool("keep-on-tv")
+		if after <= 0 || interval <= 0 {
+			exitWithError(errors.New("--after and --interval must be positive durations"))
+		}
+
+		timer := &idleTimer{after: after, now: time.Now}
+		fmt.Printf("Turning %s off after %s of inactivity\n", currentSpeaker.Name, after)
+		for {
+			active, err := speakerActive(currentSpeaker, keepOnTV)
+			if err != nil {
+				// The speaker might be busy switching source, try again next round
+				fmt.Fprintln(os.Stderr, "Error getting speaker state:", err)
+			} else if timer.Update(active) {
+				fmt.Printf("%s has been idle for %s, turning it off\n", currentSpeaker.Name, after)
+				if err := currentSpeaker.PowerOff(); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+				}
+				timer.Reset()
+			}
+			time.Sleep(interval)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(autoOffCmd)
+	autoOffCmd.Flags().Duration("after", 30*time.Minute, "Idle time before turning the speakers off")
+	autoOffCmd.Flags().Duration("interval", 10*time.Second, "How often to check the speaker state")
+	autoOffCmd.Flags().Bool("keep-on-tv", true, "Never turn off while on TV, optical or other wired inputs")
+}
+
+// speakerActive reports whether the speaker should be considered in use.
+// A speaker in standby is not active, but there is nothing to turn off either,
+// so it is reported as active to keep the idle timer from running.
+func speakerActive(speaker *kefw2.KEFSpeaker, keepOnTV bool) (bool, error) {
+	poweredOn, err := speaker.IsPoweredOn()
+	if err != nil {
+		return false, err
+	}
+	if !poweredOn {
+		return true, nil
+	}
+	source, err := speaker.Source()
+	if err != nil {
+		return false, err
+	}
+	switch source {
+	case kefw2.SourceWiFi, kefw2.SourceBluetooth:
+		return speaker.IsPlaying()
+	case kefw2.SourceStandby:
+		return true, nil
+	default:
+		// No way of telling if the wired inputs are playing anything
+		return keepOnTV, nil
+	}
+}
+
+// idleTimer keeps track of how long the speaker has been idle.
+// The clock is injectable so the timer can be driven without waiting.
+type idleTimer struct {
+	after     time.Duration
+	now       func() time.Time
+	idleSince time.Time
+}
+
+// Update records if the speaker is currently active and reports whether
+// it has been idle for at least the configured duration.
+func (t *idleTimer) Update(active bool) bool {
+	if active {
+		t.Reset()
+		return false
+	}
+	now := t.now()
+	if t.idleSince.IsZero() {
+		t.idleSince = now
+	}
+	return now.Sub(t.idleSince) >= t.after
+}
+
+// Reset starts the idle period over
+func (t *idleTimer) Reset() {
+	t.idleSince = time.Time{}
+}
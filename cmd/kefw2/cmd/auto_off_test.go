@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock for idleTimer that only moves when told to
+type fakeClock struct {
+	current time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.current
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.current = c.current.Add(d)
+}
+
+func TestIdleTimer(t *testing.T) {
+	clock := &fakeClock{current: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	timer := &idleTimer{after: 30 * time.Minute, now: clock.now}
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		active  bool
+		want    bool
+	}{
+		{"idle starts", 0, false, false},
+		{"idle for 20 minutes", 20 * time.Minute, false, false},
+		{"playing resets the idle time", 5 * time.Minute, true, false},
+		{"idle again", time.Minute, false, false},
+		{"idle for 29 minutes since playing stopped", 29 * time.Minute, false, false},
+		{"idle for 30 minutes since playing stopped", time.Minute, false, true},
+		{"still idle", time.Minute, false, true},
+		{"playing again", time.Minute, true, false},
+		{"idle after playing", time.Minute, false, false},
+	}
+	for _, step := range steps {
+		clock.advance(step.advance)
+		if got := timer.Update(step.active); got != step.want {
+			t.Errorf("%s: Update(%t) = %t, want %t", step.name, step.active, got, step.want)
+		}
+	}
+}
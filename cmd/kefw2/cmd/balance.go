@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// balanceCmd gets or sets the left/right balance of the speakers
+var balanceCmd = &cobra.Command{
+	Use:   "balance",
+	Short: "Get or adjust the left/right balance of the speakers",
+	Long: `Get or adjust the left/right balance of the speakers.
+Use L<n> to move the balance towards the left speaker, R<n> towards the right and C to center it.
+Plain numbers work too, negative is left: kefw2 balance -- -5`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		if len(args) != 1 {
+			balance, err := currentSpeaker.GetBalance()
+			if err != nil {
+				exitWithError(err)
+			}
+			if outputJSON(cmd) {
+				printJSON(map[string]int{"balance": balance})
+				return
+			}
+			fmt.Printf("Balance is: %s\n", formatBalance(balance))
+			return
+		}
+		balance, err := parseBalance(args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+		err = currentSpeaker.SetBalance(balance)
+		if err != nil {
+			exitWithError(err)
+		}
+	},
+	ValidArgsFunction: BalanceCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(balanceCmd)
+}
+
+func parseBalance(balance string) (int, error) {
+	balance = strings.ToUpper(balance)
+	if balance == "C" || balance == "CENTER" {
+		return 0, nil
+	}
+	sign := 1
+	switch {
+	case strings.HasPrefix(balance, "L"):
+		sign = -1
+		balance = balance[1:]
+	case strings.HasPrefix(balance, "R"):
+		balance = balance[1:]
+	}
+	var b int
+	_, err := fmt.Sscanf(balance, "%d", &b)
+	if err != nil {
+		return 0, fmt.Errorf("balance must be L<n>, R<n>, C or a number between %d and %d", kefw2.MinBalance, kefw2.MaxBalance)
+	}
+	b *= sign
+	if b < kefw2.MinBalance || b > kefw2.MaxBalance {
+		return 0, fmt.Errorf("balance must be between L%d and R%d", -kefw2.MinBalance, kefw2.MaxBalance)
+	}
+	return b, nil
+}
+
+func formatBalance(balance int) string {
+	switch {
+	case balance < 0:
+		return fmt.Sprintf("L%d", -balance)
+	case balance > 0:
+		return fmt.Sprintf("R%d", balance)
+	default:
+		return "C"
+	}
+}
+
+func BalanceCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"L10", "L5", "C", "R5", "R10"}, cobra.ShellCompDirectiveNoFileComp
+}
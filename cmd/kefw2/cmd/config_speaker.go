@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/hilli/go-kef-w2/kefw2"
 	"github.com/spf13/cobra"
@@ -25,7 +28,9 @@ func init() {
 	speakerCmd.AddCommand(speakerSetDefaultCmd)
 	speakerCmd.AddCommand(speakerDiscoverCmd)
 	speakerDiscoverCmd.PersistentFlags().BoolP("save", "", false, "Save the discovered speakers to config file")
-	speakerDiscoverCmd.PersistentFlags().IntP("timeout", "t", 1, "Set the timeout for speaker discovery (seconds)")
+	speakerDiscoverCmd.PersistentFlags().VarP(newSecondsDuration(time.Second, new(time.Duration)), "browse-time", "t", "How long to browse the network for speakers, ie. 5s or 5 (seconds)")
+	speakerDiscoverCmd.PersistentFlags().Int("workers", 8, "Number of discovered hosts to check at the same time")
+	speakerDiscoverCmd.PersistentFlags().Duration("host-timeout", time.Second, "How long to wait for each discovered host to answer")
 }
 
 var speakerDiscoverCmd = &cobra.Command{
@@ -34,20 +39,29 @@ var speakerDiscoverCmd = &cobra.Command{
 	Long:  `Discover speakers with mDNS`,
 	Run: func(cmd *cobra.Command, args []string) {
 		save, _ := cmd.Flags().GetBool("save")
-		timeout, _ := cmd.Flags().GetInt("timeout")
+		browseTime, _ := cmd.Flags().GetDuration("browse-time")
+		if cmd.Flags().Changed("timeout") && !cmd.Flags().Changed("browse-time") {
+			// --timeout used to be the discovery time, and the request timeout isn't used by discovery
+			fmt.Fprintln(os.Stderr, "Flag --timeout for discover is deprecated, use --browse-time instead")
+			browseTime = requestTimeout
+		}
+		workers, _ := cmd.Flags().GetInt("workers")
+		hostTimeout, _ := cmd.Flags().GetDuration("host-timeout")
+		discoverOptions := []kefw2.DiscoverOption{kefw2.WithWorkers(workers), kefw2.WithHostTimeout(hostTimeout)}
 
-		newSpeakers, err := kefw2.DiscoverSpeakers(timeout)
-		if err != nil {
-			fmt.Println(err)
-			return
+		ctx, cancel := context.WithTimeout(context.Background(), browseTime)
+		defer cancel()
+		newSpeakers, err := kefw2.DiscoverSpeakersMDNS(ctx, discoverOptions...)
+		if err != nil && len(newSpeakers) == 0 {
+			exitWithError(err)
 		}
 		if len(newSpeakers) == 0 {
 			fmt.Println("No new speakers found.")
 			fmt.Println("Make sure the speakers are connected to the same network as this computer.")
-			fmt.Println("Try extending the discovery timeout with the --timeout flag.")
+			fmt.Println("Try browsing for longer with the --browse-time flag.")
 			fmt.Println("Ie:")
 			fmt.Println()
-			fmt.Println("    kefw2 speaker discover --timeout 5 [--save]")
+			fmt.Println("    kefw2 config speaker discover --browse-time 5s [--save]")
 			fmt.Println()
 			fmt.Println("Or try adding the speaker manually with:")
 			fmt.Println()
@@ -58,7 +72,7 @@ var speakerDiscoverCmd = &cobra.Command{
 			fmt.Printf("Found speaker: %s (%s)\n", speaker.Name, speaker.IPAddress)
 			if save {
 				if err := addSpeaker(speaker.IPAddress); err != nil {
-					fmt.Printf("Error adding speaker (%s): %s\n", speaker.IPAddress, err)
+					fmt.Fprintf(os.Stderr, "Error adding speaker (%s): %s\n", speaker.IPAddress, err)
 				}
 			}
 		}
@@ -71,7 +85,7 @@ var speakerAddCmd = &cobra.Command{
 	Long:  `Add a speaker`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := addSpeaker(args[0]); err != nil {
-			fmt.Printf("Error adding speaker (%s): %s\n", args[0], err)
+			exitWithError(fmt.Errorf("error adding speaker (%s): %w", args[0], err))
 		}
 	},
 }
@@ -83,11 +97,10 @@ var speakerRemoveCmd = &cobra.Command{
 	Long:    `Remove a speaker`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) != 1 {
-			fmt.Println("Error: missing speaker IP address")
-			return
+			exitWithError(errors.New("missing speaker IP address"))
 		}
 		if err := removeSpeaker(args[0]); err != nil {
-			fmt.Printf("Error removing speaker (%s): %s\n", args[0], err)
+			exitWithError(fmt.Errorf("error removing speaker (%s): %w", args[0], err))
 		}
 	},
 }
@@ -115,7 +128,7 @@ var speakerSetDefaultCmd = &cobra.Command{
 			return
 		}
 		if err := setDefaultSpeaker(args[0]); err != nil {
-			fmt.Printf("Error setting default speaker (%s): %s\n", args[0], err)
+			exitWithError(fmt.Errorf("error setting default speaker (%s): %w", args[0], err))
 		}
 	},
 	ValidArgsFunction: ConfiguredSpeakersCompletion,
@@ -178,6 +191,19 @@ func ConfiguredSpeakersCompletion(cmd *cobra.Command, args []string, toComplete
 	return result, cobra.ShellCompDirectiveNoFileComp
 }
 
+// SpeakerFlagCompletion completes the --speaker flag with the configured speakers,
+// by name with the IP address as description and by IP address with the name as description
+func SpeakerFlagCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	result := []string{}
+	for _, speaker := range speakers {
+		if speaker.Name != "" {
+			result = append(result, fmt.Sprintf("%s\t%s", speaker.Name, speaker.IPAddress))
+		}
+		result = append(result, fmt.Sprintf("%s\t%s", speaker.IPAddress, speaker.Name))
+	}
+	return result, cobra.ShellCompDirectiveNoFileComp
+}
+
 func speakerDefined(host string) bool {
 	for _, speaker := range speakers {
 		if speaker.IPAddress == host {
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// dspCmd groups the DSP settings commands
+var dspCmd = &cobra.Command{
+	Use:   "dsp",
+	Short: "Get or adjust the DSP settings of the speakers",
+	Long: `Get or adjust the DSP settings (desk/wall mode, treble, bass extension etc.) of the speakers.
+DSP settings are part of the EQ profile of the current source.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var dspGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get the DSP settings of the speakers",
+	Long:  `Get the DSP settings of the speakers`,
+	Args:  cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		dsp, err := currentSpeaker.GetDSPSettings()
+		if err != nil {
+			exitWithError(err)
+		}
+		fmt.Println(dsp)
+	},
+}
+
+var dspSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Adjust the DSP settings of the speakers",
+	Long:  `Adjust the DSP settings of the speakers. Only the given flags are changed.`,
+	Args:  cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		dsp, err := currentSpeaker.GetDSPSettings()
+		if err != nil {
+			exitWithError(err)
+		}
+		flags := cmd.Flags()
+		if flags.Changed("desk-mode") {
+			dsp.DeskMode, _ = flags.GetBool("desk-mode")
+		}
+		if flags.Changed("desk-mode-setting") {
+			dsp.DeskModeSetting, _ = flags.GetInt("desk-mode-setting")
+		}
+		if flags.Changed("wall-mode") {
+			dsp.WallMode, _ = flags.GetBool("wall-mode")
+		}
+		if flags.Changed("wall-mode-setting") {
+			dsp.WallModeSetting, _ = flags.GetFloat32("wall-mode-setting")
+		}
+		if flags.Changed("treble-trim") {
+			dsp.TrebleAmount, _ = flags.GetFloat32("treble-trim")
+		}
+		if flags.Changed("bass-extension") {
+			dsp.BassExtension, _ = flags.GetString("bass-extension")
+		}
+		if flags.Changed("phase-correction") {
+			dsp.PhaseCorrection, _ = flags.GetBool("phase-correction")
+		}
+		if flags.Changed("high-pass") {
+			dsp.HighPassMode, _ = flags.GetBool("high-pass")
+		}
+		if flags.Changed("high-pass-freq") {
+			dsp.HighPassModeFreq, _ = flags.GetInt("high-pass-freq")
+		}
+		err = currentSpeaker.SetDSPSettings(dsp)
+		if err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dspCmd)
+	dspCmd.AddCommand(dspGetCmd, dspSetCmd)
+	dspSetCmd.Flags().Bool("desk-mode", false, "Enable desk mode")
+	dspSetCmd.Flags().Int("desk-mode-setting", 0, "Desk mode attenuation in dB (-6 to 0)")
+	dspSetCmd.Flags().Bool("wall-mode", false, "Enable wall mode")
+	dspSetCmd.Flags().Float32("wall-mode-setting", 0, "Wall mode attenuation in dB (-6 to 0)")
+	dspSetCmd.Flags().Float32("treble-trim", 0, "Treble trim in dB (-3 to 3)")
+	dspSetCmd.Flags().String("bass-extension", "standard", "Bass extension (less, standard, more)")
+	dspSetCmd.Flags().Bool("phase-correction", false, "Enable phase correction")
+	dspSetCmd.Flags().Bool("high-pass", false, "Enable the high pass filter")
+	dspSetCmd.Flags().Int("high-pass-freq", 0, "High pass filter frequency in Hz (50 to 120)")
+	dspSetCmd.RegisterFlagCompletionFunc("bass-extension", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return kefw2.BassExtensions, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -13,6 +13,7 @@ var eqProfileCmd = &cobra.Command{
 	Long:  `Get the equaliser Profile of the speakers`,
 	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
 		eqProfile, _ := currentSpeaker.GetEQProfileV2()
 		fmt.Println(eqProfile)
 	},
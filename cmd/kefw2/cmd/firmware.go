@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// firmwareStatus is the firmware status printed by `firmware status --json`
+type firmwareStatus struct {
+	Current     string `json:"current"`
+	Available   string `json:"available"`
+	UpdateReady bool   `json:"update_ready"`
+}
+
+// firmwareCmd groups the firmware commands
+var firmwareCmd = &cobra.Command{
+	Use:   "firmware",
+	Short: "Firmware of the speakers",
+	Long:  `Firmware of the speakers`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var firmwareStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show if a firmware update is available",
+	Long: `Show the installed firmware version and if the speaker has found an update.
+The update itself is installed from the KEF Connect app.`,
+	Args: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		current, available, updateReady, err := currentSpeaker.GetFirmwareUpdateStatus(context.Background())
+		if err != nil {
+			exitWithError(err)
+		}
+		if outputJSON(cmd) {
+			printJSON(firmwareStatus{Current: current, Available: available, UpdateReady: updateReady})
+			return
+		}
+		fmt.Println("Firmware:", current)
+		switch {
+		case updateReady:
+			fmt.Printf("Update available: %s. Install it from the KEF Connect app\n", available)
+		case available == kefw2.FirmwareVersionUnknown:
+			fmt.Println("Update available: unknown, the speaker's upgrade info isn't a version")
+		default:
+			fmt.Println("Firmware is up to date")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(firmwareCmd)
+	firmwareCmd.AddCommand(firmwareStatusCmd)
+	firmwareStatusCmd.Flags().BoolP("json", "j", false, "Output as JSON")
+}
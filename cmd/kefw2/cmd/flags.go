@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// secondsDuration is a duration flag that also takes a bare number as seconds,
+// so flags that used to be whole seconds, like discover --timeout 5, keep working
+type secondsDuration time.Duration
+
+func newSecondsDuration(value time.Duration, p *time.Duration) *secondsDuration {
+	*p = value
+	return (*secondsDuration)(p)
+}
+
+func (d *secondsDuration) Set(s string) error {
+	if seconds, err := strconv.Atoi(s); err == nil {
+		*d = secondsDuration(time.Duration(seconds) * time.Second)
+		return nil
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("must be a duration like 5s or a number of seconds")
+	}
+	*d = secondsDuration(duration)
+	return nil
+}
+
+func (d *secondsDuration) String() string {
+	return time.Duration(*d).String()
+}
+
+// Type is duration, so the flag can be read with GetDuration
+func (d *secondsDuration) Type() string {
+	return "duration"
+}
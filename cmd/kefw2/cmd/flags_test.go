@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func TestSecondsDuration(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{arg: "5", want: 5 * time.Second},
+		{arg: "5s", want: 5 * time.Second},
+		{arg: "1500ms", want: 1500 * time.Millisecond},
+		{arg: "five", wantErr: true},
+	}
+	for _, tt := range tests {
+		var browseTime time.Duration
+		flags := pflag.NewFlagSet("discover", pflag.ContinueOnError)
+		flags.VarP(newSecondsDuration(time.Second, &browseTime), "browse-time", "t", "")
+		err := flags.Parse([]string{"-t", tt.arg})
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("-t %s: expected an error", tt.arg)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("-t %s: %v", tt.arg, err)
+			continue
+		}
+		got, err := flags.GetDuration("browse-time")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want || browseTime != tt.want {
+			t.Errorf("-t %s = %s, want %s", tt.arg, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// groupCmd groups the speaker grouping commands
+var groupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Speaker grouping of the speakers",
+	Long:  `Show how the speakers are grouped, ie. which speaker is the master and which is the follower of a pair`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var groupStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the master and follower of the speakers",
+	Long:  `Show the master and follower of the speakers`,
+	Args:  cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		grouping, err := currentSpeaker.GetGrouping()
+		if err != nil {
+			exitWithError(err)
+		}
+		if outputJSON(cmd) {
+			printJSON(grouping)
+			return
+		}
+		if len(grouping.GroupingMembers) == 0 {
+			fmt.Println("No grouping members reported")
+			return
+		}
+		for _, member := range grouping.GroupingMembers {
+			fmt.Printf("Master: %s (%s)\n", member.Master.Name, member.Master.Id)
+			if member.Follower.Id == "" && member.Follower.Name == "" {
+				fmt.Println("Follower: none")
+				continue
+			}
+			fmt.Printf("Follower: %s (%s)\n", member.Follower.Name, member.Follower.Id)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(groupCmd)
+	groupCmd.AddCommand(groupStatusCmd)
+	groupStatusCmd.Flags().BoolP("json", "j", false, "Output as JSON")
+}
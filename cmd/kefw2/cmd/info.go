@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// speakerInfo is the hardware overview printed by the info command
+type speakerInfo struct {
+	kefw2.KEFSpeaker
+	NetworkMode  kefw2.CableMode    `json:"network_mode"`
+	Capabilities kefw2.Capabilities `json:"capabilities"`
+	// ReachableSince is inferred by kefw2, see reachableSince
+	ReachableSince *time.Time `json:"reachable_since,omitempty"`
+}
+
+// infoCmd shows the hardware details of the speakers
+var infoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Hardware details of the speakers",
+	Long:  `Model, firmware, network, volume limit and capability details of the speakers`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		if err := currentSpeaker.UpdateInfo(); err != nil {
+			exitWithError(err)
+		}
+		since, err := newReachableSince().Reached(currentSpeaker.IPAddress)
+		if err != nil {
+			log.Debug("Could not save the first contact with the speaker: ", err)
+		}
+		networkMode, err := currentSpeaker.NetworkOperationMode()
+		if err != nil {
+			exitWithError(err)
+		}
+		capabilities, err := currentSpeaker.Capabilities()
+		if err != nil {
+			exitWithError(err)
+		}
+		info := speakerInfo{
+			KEFSpeaker:   *currentSpeaker,
+			NetworkMode:  networkMode,
+			Capabilities: capabilities,
+		}
+		if !since.IsZero() {
+			info.ReachableSince = &since
+		}
+
+		if outputJSON(cmd) {
+			printJSON(info)
+			return
+		}
+		printSpeakerInfo(os.Stdout, info)
+	},
+}
+
+// printSpeakerInfo writes the info as text, one detail per line
+func printSpeakerInfo(w io.Writer, info speakerInfo) {
+	fmt.Fprintln(w, "Name:", info.Name)
+	fmt.Fprintln(w, "Model:", info.Model)
+	fmt.Fprintln(w, "Firmware:", info.FirmwareVersion)
+	fmt.Fprintln(w, "IP Address:", info.IPAddress)
+	fmt.Fprintln(w, "MAC Address:", info.MacAddress)
+	fmt.Fprintln(w, "Speaker ID:", info.Id)
+	fmt.Fprintln(w, "Network mode:", info.NetworkMode)
+	fmt.Fprintf(w, "Max volume: %d%%\n", info.MaxVolume)
+	fmt.Fprintln(w, "Capabilities:", capabilitiesText(info.Capabilities))
+	if info.ReachableSince != nil {
+		fmt.Fprintf(w, "Reachable since: %s (first contact by kefw2)\n", info.ReachableSince.Format(time.DateTime))
+	}
+}
+
+// capabilitiesText lists the available capabilities, ie. "playback control, DSP"
+func capabilitiesText(capabilities kefw2.Capabilities) string {
+	available := []string{}
+	if capabilities.PlaybackControl {
+		available = append(available, "playback control")
+	}
+	if capabilities.DSP {
+		available = append(available, "DSP")
+	}
+	if capabilities.Subwoofer {
+		available = append(available, "subwoofer")
+	}
+	if len(available) == 0 {
+		return "none"
+	}
+	return strings.Join(available, ", ")
+}
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+	infoCmd.Flags().BoolP("json", "j", false, "Output as JSON")
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+)
+
+func TestPrintSpeakerInfo(t *testing.T) {
+	info := speakerInfo{
+		KEFSpeaker: kefw2.KEFSpeaker{
+			IPAddress:       "10.0.0.93",
+			Name:            "Living Room",
+			Model:           "KEF LS50 II Wireless",
+			FirmwareVersion: "V26120",
+			MacAddress:      "84:17:15:00:AB:CD",
+			Id:              "ls502w-0123",
+			MaxVolume:       75,
+		},
+		NetworkMode:  kefw2.Wired,
+		Capabilities: kefw2.Capabilities{PlaybackControl: true, DSP: true},
+	}
+	since := time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC)
+	info.ReachableSince = &since
+	var out bytes.Buffer
+	printSpeakerInfo(&out, info)
+
+	for _, want := range []string{
+		"Name: Living Room\n",
+		"Model: KEF LS50 II Wireless\n",
+		"Firmware: V26120\n",
+		"IP Address: 10.0.0.93\n",
+		"MAC Address: 84:17:15:00:AB:CD\n",
+		"Speaker ID: ls502w-0123\n",
+		"Network mode: wired\n",
+		"Max volume: 75%\n",
+		"Capabilities: playback control, DSP\n",
+		"Reachable since: 2026-10-16 08:30:00 (first contact by kefw2)\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output is missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCapabilitiesText(t *testing.T) {
+	tests := []struct {
+		capabilities kefw2.Capabilities
+		want         string
+	}{
+		{kefw2.Capabilities{}, "none"},
+		{kefw2.Capabilities{Subwoofer: true}, "subwoofer"},
+		{kefw2.Capabilities{PlaybackControl: true, DSP: true, Subwoofer: true}, "playback control, DSP, subwoofer"},
+	}
+	for _, tt := range tests {
+		if got := capabilitiesText(tt.capabilities); got != tt.want {
+			t.Errorf("capabilitiesText(%+v) = %q, want %q", tt.capabilities, got, tt.want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -15,25 +14,43 @@ var maxVolumeCmd = &cobra.Command{
 	Long:    `Get or adjust the max volume of the speakers`,
 	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
 		if len(args) != 1 {
-			volume, _ := currentSpeaker.GetMaxVolume()
+			volume, err := currentSpeaker.GetMaxVolume()
+			if err != nil {
+				exitWithError(err)
+			}
+			if outputJSON(cmd) {
+				printJSON(map[string]int{"max_volume": volume})
+				return
+			}
 			fmt.Printf("Max volume is: %d%%\n", volume)
 			return
 		}
 		volume, err := parseVolume(args[0])
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 		err = currentSpeaker.SetMaxVolume(volume)
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 	},
 	ValidArgsFunction: VolumeCompletion,
 }
 
+// speakerMaxVolumeCmd is maxvolume under config speaker, next to the other per speaker settings
+var speakerMaxVolumeCmd = &cobra.Command{
+	Use:               "maxvolume [0-100]",
+	Aliases:           []string{"maxvol"},
+	Short:             "Get or adjust the max volume of the speakers",
+	Long:              `Get or adjust the max volume of the speakers. Volume changes, also relative ones, never go above it.`,
+	Args:              cobra.MaximumNArgs(1),
+	Run:               maxVolumeCmd.Run,
+	ValidArgsFunction: VolumeCompletion,
+}
+
 func init() {
 	ConfigCmd.AddCommand(maxVolumeCmd)
+	speakerCmd.AddCommand(speakerMaxVolumeCmd)
 }
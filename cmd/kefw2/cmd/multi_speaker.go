@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// multiSpeakerWorkers is the number of speakers operated on at the same time
+const multiSpeakerWorkers = 4
+
+// speakerResult is the outcome of running an action on one speaker
+type speakerResult struct {
+	target  string
+	speaker *kefw2.KEFSpeaker
+	value   any
+	text    string
+	err     error
+}
+
+// name is the speaker name and IP address, or the target if the speaker wasn't found
+func (r speakerResult) name() string {
+	if r.speaker == nil {
+		return r.target
+	}
+	return fmt.Sprintf("%s (%s)", r.speaker.Name, r.speaker.IPAddress)
+}
+
+// addMultiSpeakerFlags adds the --all and --speakers flags to cmd and its subcommands
+func addMultiSpeakerFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool("all", false, "Operate on all configured speakers")
+	cmd.PersistentFlags().StringSlice("speakers", nil, "Operate on these configured speakers (comma separated names or IPs)")
+	cmd.RegisterFlagCompletionFunc("speakers", ConfiguredSpeakersCompletion)
+}
+
+// targetSpeakers returns the speakers given with --all or --speakers,
+// or nil if the command should just operate on the current speaker
+func targetSpeakers(cmd *cobra.Command) []string {
+	if all, _ := cmd.Flags().GetBool("all"); all {
+		targets := []string{}
+		for _, speaker := range speakers {
+			targets = append(targets, speaker.IPAddress)
+		}
+		return targets
+	}
+	targets, _ := cmd.Flags().GetStringSlice("speakers")
+	if len(targets) == 0 {
+		return nil
+	}
+	return targets
+}
+
+// runOnSpeakers runs fn on the speakers given with --all or --speakers and exits
+// non-zero if any of them failed. It returns false if neither flag was given,
+// in which case the command should operate on the current speaker as usual.
+func runOnSpeakers(cmd *cobra.Command, fn func(*kefw2.KEFSpeaker) error) bool {
+	return getOnSpeakers(cmd, "", func(speaker *kefw2.KEFSpeaker) (any, string, error) {
+		return nil, "ok", fn(speaker)
+	})
+}
+
+// getOnSpeakers is runOnSpeakers for getters. The text of each speaker's value is printed after its name,
+// or with JSON output an array is printed with an object per speaker holding the value under key.
+// Speakers that failed are reported on stderr, or with their error in the JSON.
+func getOnSpeakers(cmd *cobra.Command, key string, get func(*kefw2.KEFSpeaker) (value any, text string, err error)) bool {
+	targets := targetSpeakers(cmd)
+	if targets == nil {
+		return false
+	}
+	results, err := forEachSpeaker(targets, get)
+	if err != nil {
+		exitWithError(err)
+	}
+	if outputJSON(cmd) {
+		printJSON(speakerResultsJSON(results, key))
+	}
+	failed := 0
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			failed++
+			if !outputJSON(cmd) {
+				fmt.Fprintf(os.Stderr, "%s: failed: %s\n", result.name(), result.err)
+			}
+		case !outputJSON(cmd):
+			fmt.Printf("%s: %s\n", result.name(), result.text)
+		}
+	}
+	if failed > 0 {
+		exitWithError(fmt.Errorf("%d of %d speakers failed", failed, len(results)))
+	}
+	return true
+}
+
+// speakerResultsJSON is an object per speaker with its value under key, or its error
+func speakerResultsJSON(results []speakerResult, key string) []map[string]any {
+	jsonResults := []map[string]any{}
+	for _, result := range results {
+		jsonResult := map[string]any{"target": result.target}
+		if result.speaker != nil {
+			jsonResult["name"] = result.speaker.Name
+			jsonResult["ip_address"] = result.speaker.IPAddress
+		}
+		if result.err != nil {
+			jsonResult["error"] = result.err.Error()
+		} else if key != "" {
+			jsonResult[key] = result.value
+		}
+		jsonResults = append(jsonResults, jsonResult)
+	}
+	return jsonResults
+}
+
+// forEachSpeaker runs fn concurrently on the configured speakers matching names (name or IP).
+// All speakers are attempted and the result of each is returned in the order of names.
+func forEachSpeaker(names []string, fn func(*kefw2.KEFSpeaker) (any, string, error)) ([]speakerResult, error) {
+	if len(names) == 0 {
+		return nil, errors.New("no speakers configured")
+	}
+	results := make([]speakerResult, len(names))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(multiSpeakerWorkers, len(names)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i].target = names[i]
+				speaker, err := configuredSpeaker(names[i])
+				if err == nil {
+					results[i].speaker = speaker
+					results[i].value, results[i].text, err = fn(speaker)
+				}
+				results[i].err = err
+			}
+		}()
+	}
+	for i := range names {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results, nil
+}
+
+// configuredSpeaker finds a speaker in the config by name or IP address
+func configuredSpeaker(host string) (*kefw2.KEFSpeaker, error) {
+	for _, speaker := range speakers {
+		if speaker.IPAddress == host || speaker.Name == host {
+			speaker.SetOptions(speakerOptions()...)
+			return &speaker, nil
+		}
+	}
+	return nil, errors.New("speaker not found in config")
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+)
+
+func TestSpeakerResultsJSON(t *testing.T) {
+	results := []speakerResult{
+		{target: "Living Room", speaker: &kefw2.KEFSpeaker{Name: "Living Room", IPAddress: "10.0.0.93"}, value: 30, text: "Volume is: 30%"},
+		{target: "Kitchen", err: errors.New("speaker not found in config")},
+	}
+	out, err := json.Marshal(speakerResultsJSON(results, "volume"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"ip_address":"10.0.0.93","name":"Living Room","target":"Living Room","volume":30},{"error":"speaker not found in config","target":"Kitchen"}]`
+	if string(out) != want {
+		t.Errorf("got  %s\nwant %s", out, want)
+	}
+}
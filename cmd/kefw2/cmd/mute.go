@@ -2,8 +2,8 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
+	"github.com/hilli/go-kef-w2/kefw2"
 	"github.com/spf13/cobra"
 )
 
@@ -15,23 +15,44 @@ var muteCmd = &cobra.Command{
 	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) != 1 {
-			mute, _ := currentSpeaker.IsMuted()
+			if getOnSpeakers(cmd, "muted", func(speaker *kefw2.KEFSpeaker) (any, string, error) {
+				mute, err := speaker.IsMuted()
+				return mute, fmt.Sprintf("Speakers are muted: %t", mute), err
+			}) {
+				return
+			}
+			requireSpeaker()
+			mute, err := currentSpeaker.IsMuted()
+			if err != nil {
+				exitWithError(err)
+			}
+			if outputJSON(cmd) {
+				printJSON(map[string]bool{"muted": mute})
+				return
+			}
 			fmt.Printf("Speakers are muted: %t\n", mute)
 			return
 		}
 		mute, err := parseMuteArg(args[0])
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		}
+		if runOnSpeakers(cmd, func(speaker *kefw2.KEFSpeaker) error {
+			if mute {
+				return speaker.Mute()
+			}
+			return speaker.Unmute()
+		}) {
+			return
+		}
+		requireSpeaker()
 		if mute {
 			err = currentSpeaker.Mute()
 		} else {
 			err = currentSpeaker.Unmute()
 		}
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 	},
 	ValidArgsFunction: MuteCompletion,
@@ -39,6 +60,7 @@ var muteCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(muteCmd)
+	addMultiSpeakerFlags(muteCmd)
 }
 
 func MuteCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// notifyCmd shows a desktop notification with the playing track
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Show a desktop notification with the playing track",
+	Long: `Show a desktop notification with the playing track.
+With --daemon it keeps running and shows a notification whenever the track changes.
+Tracks skipped within --debounce don't get a notification.
+Notifications are shown with notify-send on Linux and osascript on macOS.`,
+	Args: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		daemon, _ := cmd.Flags().GetBool("daemon")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		debounce, _ := cmd.Flags().GetDuration("debounce")
+		desktop := desktopNotifier{}
+		if !daemon {
+			pd, err := currentSpeaker.PlayerData()
+			if err != nil {
+				exitWithError(err)
+			}
+			n, _, ok := trackNotification(currentSpeaker, pd)
+			if !ok {
+				exitWithError(fmt.Errorf("nothing is playing"))
+			}
+			if err := desktop.Notify(n); err != nil {
+				exitWithError(err)
+			}
+			return
+		}
+		if interval <= 0 {
+			exitWithError(fmt.Errorf("--interval must be a positive duration"))
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		tracks := &trackNotifier{notifier: desktop, debounce: debounce, now: time.Now}
+		updates, errs := currentSpeaker.WatchPlayerData(ctx, interval)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case pd, ok := <-updates:
+				if !ok {
+					return
+				}
+				tracks.Update(currentSpeaker, pd)
+			case err, ok := <-errs:
+				if !ok {
+					return
+				}
+				log.Debug("Error getting player data: ", err)
+			case <-ticker.C:
+			}
+			if err := tracks.Flush(); err != nil {
+				fmt.Fprintln(os.Stderr, "Error showing notification:", err)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.Flags().Bool("daemon", false, "Keep running and notify whenever the track changes")
+	notifyCmd.Flags().Duration("interval", 2*time.Second, "How often to check the playing track with --daemon")
+	notifyCmd.Flags().Duration("debounce", 3*time.Second, "How long a track must play before it gets a notification with --daemon")
+}
+
+// notification is a desktop notification about the playing track
+type notification struct {
+	Title   string
+	Message string
+	// ArtURL is the album art, empty if there is none
+	ArtURL string
+}
+
+// notifier shows notifications. The tests use a notifier collecting them instead of showing them.
+type notifier interface {
+	Notify(n notification) error
+}
+
+// trackNotification builds the notification for the playing track in pd.
+// key identifies the track, and ok is false when nothing is playing.
+func trackNotification(speaker *kefw2.KEFSpeaker, pd kefw2.PlayerData) (n notification, key string, ok bool) {
+	if pd.State != "playing" {
+		return notification{}, "", false
+	}
+	title, artist := pd.StreamMetadata()
+	details := []string{}
+	if artist != "" {
+		details = append(details, artist)
+	}
+	album := pd.TrackRoles.MediaData.MetaData.Album
+	if pd.IsLive() && title != pd.MediaRoles.Title {
+		// Radio stations have no album, but the station name is worth showing
+		album = pd.MediaRoles.Title
+	}
+	if album != "" {
+		details = append(details, album)
+	}
+	n = notification{Title: title, Message: strings.Join(details, " - ")}
+	n.ArtURL, _ = speaker.ArtworkURL(pd)
+	key = strings.Join([]string{pd.MediaRoles.Title, title, artist, album}, "\x00")
+	return n, key, true
+}
+
+// trackNotifier sends one notification per distinct track. A track is only notified once it
+// has been playing for debounce, so skipping through tracks doesn't flood the desktop.
+// The clock is injectable so the debounce can be tested without waiting.
+type trackNotifier struct {
+	notifier notifier
+	debounce time.Duration
+	now      func() time.Time
+
+	pending      *notification
+	pendingKey   string
+	pendingSince time.Time
+	notifiedKey  string
+}
+
+// Update records the player data of a poll
+func (t *trackNotifier) Update(speaker *kefw2.KEFSpeaker, pd kefw2.PlayerData) {
+	n, key, ok := trackNotification(speaker, pd)
+	if !ok {
+		t.pending = nil
+		return
+	}
+	if t.pending != nil && key == t.pendingKey {
+		return
+	}
+	t.pending = &n
+	t.pendingKey = key
+	t.pendingSince = t.now()
+}
+
+// Flush sends the notification of the playing track if it has played for debounce and hasn't been notified yet
+func (t *trackNotifier) Flush() error {
+	if t.pending == nil || t.pendingKey == t.notifiedKey || t.now().Sub(t.pendingSince) < t.debounce {
+		return nil
+	}
+	t.notifiedKey = t.pendingKey
+	return t.notifier.Notify(*t.pending)
+}
+
+// desktopNotifier shows notifications with the notification tool of the OS
+type desktopNotifier struct{}
+
+func (desktopNotifier) Notify(n notification) error {
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		args := []string{"--app-name=kefw2"}
+		if icon := downloadArt(n.ArtURL); icon != "" {
+			args = append(args, "--icon="+icon)
+		}
+		return exec.Command("notify-send", append(args, n.Title, n.Message)...).Run()
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(n.Message), appleScriptString(n.Title))
+		return exec.Command("osascript", "-e", script).Run()
+	}
+	return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+}
+
+// downloadArt saves the album art for the notification and returns its path, or "" if there is none
+func downloadArt(artURL string) string {
+	if artURL == "" {
+		return ""
+	}
+	path := filepath.Join(os.TempDir(), "kefw2-notify-art")
+	if err := downloadFile(artURL, path); err != nil {
+		log.Debug("Could not download the album art: ", err)
+		return ""
+	}
+	return path
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
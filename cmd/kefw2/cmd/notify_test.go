@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+)
+
+// collectingNotifier keeps the notifications instead of showing them
+type collectingNotifier struct {
+	notifications []notification
+}
+
+func (c *collectingNotifier) Notify(n notification) error {
+	c.notifications = append(c.notifications, n)
+	return nil
+}
+
+func playing(title, artist, album string) kefw2.PlayerData {
+	pd := kefw2.PlayerData{State: "playing"}
+	pd.TrackRoles.Title = title
+	pd.TrackRoles.Icon = "http://art.example/" + strings.ReplaceAll(title, " ", "-") + ".jpg"
+	pd.TrackRoles.MediaData.MetaData = kefw2.PlayerMetaData{Artist: artist, Album: album}
+	return pd
+}
+
+func TestTrackNotifier(t *testing.T) {
+	speaker := &kefw2.KEFSpeaker{IPAddress: "10.0.0.93"}
+	clock := &fakeClock{current: time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)}
+	collected := &collectingNotifier{}
+	tracks := &trackNotifier{notifier: collected, debounce: 3 * time.Second, now: clock.now}
+
+	// Each poll feeds the player data, then a second passes
+	polls := []kefw2.PlayerData{
+		playing("Wish You Were Here", "Pink Floyd", "Wish You Were Here"),
+		playing("Wish You Were Here", "Pink Floyd", "Wish You Were Here"),
+		playing("Wish You Were Here", "Pink Floyd", "Wish You Were Here"),
+		playing("Wish You Were Here", "Pink Floyd", "Wish You Were Here"),
+		// Skipped through quickly, no notification
+		playing("Welcome to the Machine", "Pink Floyd", "Wish You Were Here"),
+		// Paused and resumed, no new notification
+		playing("Have a Cigar", "Pink Floyd", "Wish You Were Here"),
+		playing("Have a Cigar", "Pink Floyd", "Wish You Were Here"),
+		playing("Have a Cigar", "Pink Floyd", "Wish You Were Here"),
+		playing("Have a Cigar", "Pink Floyd", "Wish You Were Here"),
+		{State: "paused"},
+		playing("Have a Cigar", "Pink Floyd", "Wish You Were Here"),
+		playing("Have a Cigar", "Pink Floyd", "Wish You Were Here"),
+		playing("Have a Cigar", "Pink Floyd", "Wish You Were Here"),
+		playing("Have a Cigar", "Pink Floyd", "Wish You Were Here"),
+	}
+	for _, pd := range polls {
+		tracks.Update(speaker, pd)
+		if err := tracks.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		clock.advance(time.Second)
+	}
+
+	want := []notification{
+		{Title: "Wish You Were Here", Message: "Pink Floyd - Wish You Were Here", ArtURL: "http://art.example/Wish-You-Were-Here.jpg"},
+		{Title: "Have a Cigar", Message: "Pink Floyd - Wish You Were Here", ArtURL: "http://art.example/Have-a-Cigar.jpg"},
+	}
+	if len(collected.notifications) != len(want) {
+		t.Fatalf("got %d notifications, want %d: %+v", len(collected.notifications), len(want), collected.notifications)
+	}
+	for i := range want {
+		if collected.notifications[i] != want[i] {
+			t.Errorf("notification %d = %+v, want %+v", i, collected.notifications[i], want[i])
+		}
+	}
+}
+
+func TestTrackNotification(t *testing.T) {
+	speaker := &kefw2.KEFSpeaker{IPAddress: "10.0.0.93"}
+
+	if _, _, ok := trackNotification(speaker, kefw2.PlayerData{State: "stopped"}); ok {
+		t.Error("got a notification while nothing is playing")
+	}
+
+	// Art served by the speaker is resolved against its address
+	pd := playing("So What", "Miles Davis", "")
+	pd.TrackRoles.Icon = "/file/art.jpg"
+	n, _, ok := trackNotification(speaker, pd)
+	want := notification{Title: "So What", Message: "Miles Davis", ArtURL: "http://10.0.0.93/file/art.jpg"}
+	if !ok || n != want {
+		t.Errorf("got %+v, %v, want %+v", n, ok, want)
+	}
+
+	// A radio station shows its name instead of an album
+	radio := kefw2.PlayerData{State: "playing"}
+	radio.MediaRoles.Title = "Radio Paradise"
+	radio.MediaRoles.MediaData.MetaData.Live = true
+	radio.TrackRoles.Title = "Teardrop"
+	radio.TrackRoles.MediaData.MetaData.Artist = "Massive Attack"
+	n, _, _ = trackNotification(speaker, radio)
+	want = notification{Title: "Teardrop", Message: "Massive Attack - Radio Paradise"}
+	if n != want {
+		t.Errorf("got %+v, want %+v", n, want)
+	}
+}
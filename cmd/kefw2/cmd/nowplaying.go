@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// nowPlayingCmd prints the playing track on a single line for status bars
+var nowPlayingCmd = &cobra.Command{
+	Use:   "nowplaying",
+	Short: "Print the playing track in a custom format",
+	Long: `Print the playing track on a single line, ie. for polybar or tmux status lines.
+The format can contain the tokens {title}, {artist}, {album}, {elapsed}, {duration} and {state}.
+Other text, including unknown tokens, is printed as is.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		format, _ := cmd.Flags().GetString("format")
+		idleText, _ := cmd.Flags().GetString("idle-text")
+		pd, err := currentSpeaker.PlayerData()
+		if err != nil {
+			exitWithError(err)
+		}
+		if pd.State != "playing" && pd.State != "paused" {
+			fmt.Println(idleText)
+			return
+		}
+		elapsed, _ := currentSpeaker.SongProgress()
+		fmt.Println(formatNowPlaying(format, pd, elapsed))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(nowPlayingCmd)
+	nowPlayingCmd.Flags().StringP("format", "f", "{artist} - {title}", "Output format")
+	nowPlayingCmd.Flags().String("idle-text", "", "Text to print when nothing is playing")
+}
+
+// formatNowPlaying replaces the tokens in format with the details of the playing track
+func formatNowPlaying(format string, pd kefw2.PlayerData, elapsed string) string {
+	duration := ""
+	if pd.Status.Duration > 0 {
+		duration = pd.Status.String()
+	}
+	return strings.NewReplacer(
+		"{title}", pd.TrackRoles.Title,
+		"{artist}", pd.TrackRoles.MediaData.MetaData.Artist,
+		"{album}", pd.TrackRoles.MediaData.MetaData.Album,
+		"{elapsed}", elapsed,
+		"{duration}", duration,
+		"{state}", pd.State,
+	).Replace(format)
+}
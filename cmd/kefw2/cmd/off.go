@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-
+	"github.com/hilli/go-kef-w2/kefw2"
 	"github.com/spf13/cobra"
 )
 
@@ -13,13 +12,18 @@ var offCmd = &cobra.Command{
 	Long:  `Turns the speakers off`,
 	Args:  cobra.MaximumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
+		if runOnSpeakers(cmd, func(speaker *kefw2.KEFSpeaker) error { return speaker.PowerOff() }) {
+			return
+		}
+		requireSpeaker()
 		err := currentSpeaker.PowerOff()
 		if err != nil {
-			fmt.Println(err)
+			exitWithError(err)
 		}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(offCmd)
+	addMultiSpeakerFlags(offCmd)
 }
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// StatusJSON is the status of the speakers as printed by `status --json`
+type StatusJSON struct {
+	PoweredOn bool         `json:"powered_on"`
+	Source    kefw2.Source `json:"source"`
+	Volume    int          `json:"volume"`
+	Muted     bool         `json:"muted"`
+	State     string       `json:"state"`
+	Track     *TrackJSON   `json:"track,omitempty"`
+}
+
+// TrackJSON is the currently playing track in StatusJSON
+type TrackJSON struct {
+	Transport  string `json:"transport"`
+	Title      string `json:"title"`
+	Artist     string `json:"artist"`
+	Album      string `json:"album"`
+	PositionMS int    `json:"position_ms"`
+	DurationMS int    `json:"duration_ms"`
+	Icon       string `json:"icon,omitempty"`
+}
+
+// outputJSON reports if the command should print JSON, either from
+// the commands own --json flag or the global --output json
+func outputJSON(cmd *cobra.Command) bool {
+	if asJSON, err := cmd.Flags().GetBool("json"); err == nil && asJSON {
+		return true
+	}
+	return outputFormat == "json"
+}
+
+// printJSON prints v as indented JSON on stdout
+func printJSON(v any) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		exitWithError(err)
+	}
+	fmt.Println(string(out))
+}
+
+// exitWithError prints err on stderr and exits with a non-zero exit code.
+// If the speaker didn't answer, its reachable since time is forgotten.
+func exitWithError(err error) {
+	if currentSpeaker != nil && errors.Is(err, kefw2.ErrSpeakerUnreachable) {
+		newReachableSince().Unreachable(currentSpeaker.IPAddress)
+	}
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// getStatusJSON collects the status of the current speaker
+func getStatusJSON() (StatusJSON, error) {
+	var status StatusJSON
+	var err error
+	if status.PoweredOn, err = currentSpeaker.IsPoweredOn(); err != nil {
+		return status, fmt.Errorf("error getting power state: %w", err)
+	}
+	if status.Source, err = currentSpeaker.Source(); err != nil {
+		return status, fmt.Errorf("error getting source: %w", err)
+	}
+	if status.Volume, err = currentSpeaker.GetVolume(); err != nil {
+		return status, fmt.Errorf("error getting volume: %w", err)
+	}
+	if status.Muted, err = currentSpeaker.IsMuted(); err != nil {
+		return status, fmt.Errorf("error getting mute state: %w", err)
+	}
+	pd, err := currentSpeaker.PlayerData()
+	if err != nil {
+		return status, err
+	}
+	status.State = pd.State
+	if pd.State == "playing" {
+		position, _ := currentSpeaker.SongProgressMS()
+		status.Track = &TrackJSON{
+			Transport:  pd.MediaRoles.Title,
+			Title:      pd.TrackRoles.Title,
+			Artist:     pd.TrackRoles.MediaData.MetaData.Artist,
+			Album:      pd.TrackRoles.MediaData.MetaData.Album,
+			PositionMS: position,
+			DurationMS: pd.Status.Duration,
+			Icon:       pd.TrackRoles.Icon,
+		}
+	}
+	return status, nil
+}
@@ -14,26 +14,16 @@ var pauseCmd = &cobra.Command{
 	Long:  `Pause playback when on WiFi source`,
 	Args:  cobra.MaximumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		canControlPlayback, err := currentSpeaker.CanControlPlayback()
-		if err != nil {
-			fmt.Printf("Can't query source: %s\n", err.Error())
-			os.Exit(1)
-		}
-		if !canControlPlayback {
-			fmt.Println("Not on WiFi/BT source.")
-			os.Exit(0)
-		}
+		requireSpeaker()
 		if isPlaying, err := currentSpeaker.IsPlaying(); err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		} else if !isPlaying {
 			fmt.Println("Not playing, not pausing")
 			os.Exit(0)
 		}
-		err = currentSpeaker.PlayPause()
+		err := currentSpeaker.PlayPause()
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 	},
 }
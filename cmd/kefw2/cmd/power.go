@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// powerCmd groups the power on/off commands
+var powerCmd = &cobra.Command{
+	Use:   "power",
+	Short: "Turn the speakers on or off",
+	Long:  `Turn the speakers on or off`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var powerOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Turns the speakers on",
+	Long: `Turns the speakers on.
+If the speakers are in network standby and don't answer, a Wake-on-LAN packet is sent automatically.
+Use --wol to always send one first.
+With --volume the speakers are turned on at that volume instead of the last one, faded in from 0 over --ramp if given.`,
+	Args: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		wol, _ := cmd.Flags().GetBool("wol")
+		volume, _ := cmd.Flags().GetInt("volume")
+		ramp, _ := cmd.Flags().GetDuration("ramp")
+		setVolume := cmd.Flags().Changed("volume")
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		powerOn := func(speaker *kefw2.KEFSpeaker) error {
+			if wol {
+				if err := speaker.WakeOnLAN(); err != nil {
+					return err
+				}
+			}
+			if setVolume {
+				return speaker.PowerOnWithVolume(ctx, volume, ramp)
+			}
+			return speaker.PowerOn()
+		}
+		if runOnSpeakers(cmd, powerOn) {
+			return
+		}
+		requireSpeaker()
+		if err := powerOn(currentSpeaker); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+var powerOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Turns the speakers off",
+	Long:  `Turns the speakers off`,
+	Args:  cobra.MaximumNArgs(0),
+	Run:   offCmd.Run,
+}
+
+func init() {
+	rootCmd.AddCommand(powerCmd)
+	powerCmd.AddCommand(powerOnCmd, powerOffCmd)
+	addMultiSpeakerFlags(powerCmd)
+	powerOnCmd.Flags().Bool("wol", false, "Send a Wake-on-LAN packet before turning the speakers on")
+	powerOnCmd.Flags().Int("volume", 0, "Volume to turn the speakers on at")
+	powerOnCmd.Flags().Duration("ramp", 0, "Fade the volume up from 0 to --volume over this long, ie. 5s")
+	powerOnCmd.RegisterFlagCompletionFunc("volume", VolumeCompletion)
+}
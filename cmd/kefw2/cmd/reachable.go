@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// reachableSince infers since when a speaker has been up, as the speakers don't report their uptime or boot time.
+// It is the first time kefw2 reached the speaker after last finding it unreachable, cached in a state file by IP address.
+// The clock is injectable so it can be tested without waiting.
+type reachableSince struct {
+	path string
+	now  func() time.Time
+}
+
+func newReachableSince() reachableSince {
+	return reachableSince{path: stateFile("reachable_since.json"), now: time.Now}
+}
+
+// Reached records a successful contact with the speaker and returns since when it has been reachable
+func (r reachableSince) Reached(ipAddress string) (time.Time, error) {
+	contacts := r.read()
+	if since, ok := contacts[ipAddress]; ok {
+		return since, nil
+	}
+	since := r.now().Truncate(time.Second)
+	contacts[ipAddress] = since
+	return since, r.write(contacts)
+}
+
+// Unreachable forgets the speaker, so the next successful contact starts a new reachable period
+func (r reachableSince) Unreachable(ipAddress string) error {
+	contacts := r.read()
+	if _, ok := contacts[ipAddress]; !ok {
+		return nil
+	}
+	delete(contacts, ipAddress)
+	return r.write(contacts)
+}
+
+// read returns the cached contacts. A missing or broken state file is the same as no contacts.
+func (r reachableSince) read() map[string]time.Time {
+	contacts := map[string]time.Time{}
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return contacts
+	}
+	if err := json.Unmarshal(data, &contacts); err != nil || contacts == nil {
+		return map[string]time.Time{}
+	}
+	return contacts
+}
+
+func (r reachableSince) write(contacts map[string]time.Time) error {
+	data, err := json.MarshalIndent(contacts, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0644)
+}
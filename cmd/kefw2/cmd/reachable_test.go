@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReachableSince(t *testing.T) {
+	clock := &fakeClock{current: time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)}
+	path := filepath.Join(t.TempDir(), "reachable_since.json")
+	reachable := reachableSince{path: path, now: clock.now}
+	start := clock.current
+
+	reached := func(want time.Time) {
+		t.Helper()
+		since, err := reachable.Reached("10.0.0.93")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !since.Equal(want) {
+			t.Errorf("reachable since %s, want %s", since, want)
+		}
+	}
+
+	// The first contact starts the reachable period, later contacts keep it
+	reached(start)
+	clock.advance(2 * time.Hour)
+	reached(start)
+
+	// The contact is cached between runs
+	reachable = reachableSince{path: path, now: clock.now}
+	reached(start)
+
+	// After the speaker was unreachable, the next contact starts over
+	if err := reachable.Unreachable("10.0.0.93"); err != nil {
+		t.Fatal(err)
+	}
+	clock.advance(time.Hour)
+	reached(clock.current)
+}
+
+func TestReachableSinceBrokenStateFile(t *testing.T) {
+	clock := &fakeClock{current: time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)}
+	path := filepath.Join(t.TempDir(), "reachable_since.json")
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reachable := reachableSince{path: path, now: clock.now}
+
+	since, err := reachable.Reached("10.0.0.93")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !since.Equal(clock.current) {
+		t.Errorf("reachable since %s, want %s", since, clock.current)
+	}
+}
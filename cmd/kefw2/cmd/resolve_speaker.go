@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	log "github.com/sirupsen/logrus"
+)
+
+// speakerOptions returns the options applied to every speaker the commands operate on
+func speakerOptions() []kefw2.SpeakerOption {
+	return []kefw2.SpeakerOption{
+		kefw2.WithTimeout(requestTimeout),
+		kefw2.WithRetry(requestRetries+1, 200*time.Millisecond),
+	}
+}
+
+// ResolveSpeaker finds the speaker to operate on.
+// An empty arg means the active speaker (see the use command), or the default speaker if none is active.
+// Otherwise arg is looked up by name or IP in the config,
+// and if it isn't configured it is tried as the address of a speaker on the network.
+func ResolveSpeaker(arg string) (*kefw2.KEFSpeaker, error) {
+	if arg == "" {
+		arg = activeSpeaker()
+	}
+	if arg == "" {
+		if defaultSpeaker == nil {
+			return nil, errors.New("default speaker not found. Set it with `kefw2 config speaker default` or specify it with the --speaker (-s) flag")
+		}
+		defaultSpeaker.SetOptions(speakerOptions()...)
+		return defaultSpeaker, nil
+	}
+	if speaker, err := configuredSpeaker(arg); err == nil {
+		return speaker, nil
+	}
+	speaker, err := kefw2.NewSpeaker(arg, speakerOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("hmm, %s is not a configured speaker and does not look like a KEF W2 speaker: %w", arg, err)
+	}
+	return &speaker, nil
+}
+
+// requireSpeaker resolves the --speaker flag (or the active or default speaker) into currentSpeaker,
+// exiting with the reason if that isn't possible. A speaker given with --speaker becomes the active speaker of the terminal.
+func requireSpeaker() {
+	speaker, err := ResolveSpeaker(currentSpeakerParam)
+	if err != nil {
+		exitWithError(err)
+	}
+	currentSpeaker = speaker
+	if currentSpeakerParam != "" {
+		if err := setActiveSpeaker(speaker.IPAddress); err != nil {
+			log.Debug("Could not save the active speaker: ", err)
+		}
+	}
+}
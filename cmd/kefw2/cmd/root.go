@@ -26,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime/debug"
+	"time"
 
 	"github.com/hilli/go-kef-w2/kefw2"
 	log "github.com/sirupsen/logrus"
@@ -41,6 +42,9 @@ var (
 	speakers            []kefw2.KEFSpeaker
 	defaultSpeaker      *kefw2.KEFSpeaker
 	currentSpeaker      *kefw2.KEFSpeaker
+	outputFormat        string
+	requestTimeout      time.Duration
+	requestRetries      int
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -111,6 +115,13 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", viper.ConfigFileUsed(), "config file")
 	rootCmd.PersistentFlags().StringVarP(&currentSpeakerParam, "speaker", "s", "", "speaker to operate on. Default speaker will be used if not specified")
+	rootCmd.RegisterFlagCompletionFunc("speaker", SpeakerFlagCompletion)
+	rootCmd.PersistentFlags().Var(newSecondsDuration(time.Second, &requestTimeout), "timeout", "how long to wait for the speaker to answer each request")
+	rootCmd.PersistentFlags().IntVar(&requestRetries, "retries", 0, "how many times to retry a request the speaker failed, ie. while it is busy switching source")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "output format: text or json")
+	rootCmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -146,16 +157,4 @@ func initConfig() {
 			break
 		}
 	}
-	if currentSpeakerParam != "" {
-		newSpeaker, err := kefw2.NewSpeaker(currentSpeakerParam)
-		if err != nil {
-			fmt.Printf("Hmm, %s does not look like it is a KEF W2 speaker:\n%s\n", currentSpeakerParam, err.Error())
-		}
-		currentSpeaker = &newSpeaker
-	} else {
-		if defaultSpeaker == nil {
-			log.Println("Default speaker not found. Set it with `kefw2 config speaker default` or specify it with the --speaker (-s) flag")
-		}
-		currentSpeaker = defaultSpeaker
-	}
 }
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// seekCmd jumps to a position in the current track
+var seekCmd = &cobra.Command{
+	Use:   "seek <position>",
+	Short: "Jump to a position in the current track when on WiFi source",
+	Long: `Jump to a position in the current track when on WiFi source.
+The position is either absolute, like 1:30, 90 or 90s, or relative to the current position, like +30s.
+Relative positions backwards needs a -- in front so they aren't mistaken for flags:
+
+    kefw2 seek -- -10s`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		relative, position, err := parseSeekPosition(args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+		ctx := context.Background()
+		switch {
+		case relative && position < 0:
+			err = currentSpeaker.SeekBackward(ctx, -position)
+		case relative:
+			err = currentSpeaker.SeekForward(ctx, position)
+		default:
+			err = currentSpeaker.Seek(ctx, position)
+		}
+		if err != nil {
+			exitWithError(err)
+		}
+	},
+	ValidArgsFunction: cobra.NoFileCompletions,
+}
+
+func init() {
+	rootCmd.AddCommand(seekCmd)
+}
+
+// errSeekPosition is the error for a position that can't be parsed
+var errSeekPosition = errors.New("position must be like 1:30, 1:02:03, 90, 90s or +30s")
+
+// parseSeekPosition parses [h:]mm:ss, plain seconds, a duration like 90s or 1m30s, or a relative +/- of those.
+// The position is returned in milliseconds.
+func parseSeekPosition(position string) (relative bool, ms int, err error) {
+	sign := 1
+	switch {
+	case strings.HasPrefix(position, "+"):
+		relative = true
+		position = position[1:]
+	case strings.HasPrefix(position, "-"):
+		relative = true
+		sign = -1
+		position = position[1:]
+	}
+
+	var d time.Duration
+	switch {
+	case strings.Contains(position, ":"):
+		parts := strings.Split(position, ":")
+		if len(parts) > 3 {
+			return false, 0, errSeekPosition
+		}
+		seconds := 0
+		for i, part := range parts {
+			n, ok := parseDigits(part)
+			// Everything but the first part is minutes or seconds
+			if !ok || (i > 0 && n > 59) {
+				return false, 0, errSeekPosition
+			}
+			seconds = seconds*60 + n
+		}
+		d = time.Duration(seconds) * time.Second
+	default:
+		if seconds, ok := parseDigits(position); ok {
+			d = time.Duration(seconds) * time.Second
+			break
+		}
+		d, err = time.ParseDuration(position)
+		if err != nil || d < 0 {
+			return false, 0, errSeekPosition
+		}
+	}
+	return relative, sign * int(d/time.Millisecond), nil
+}
+
+// parseDigits parses a number of only digits, without sign or anything after it
+func parseDigits(s string) (int, bool) {
+	if s == "" || strings.Trim(s, "0123456789") != "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
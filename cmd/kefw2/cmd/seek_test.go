@@ -0,0 +1,49 @@
+package cmd
+
+import "testing"
+
+func TestParseSeekPosition(t *testing.T) {
+	tests := []struct {
+		position     string
+		wantRelative bool
+		wantMS       int
+		wantErr      bool
+	}{
+		{position: "1:30", wantMS: 90000},
+		{position: "0:05", wantMS: 5000},
+		{position: "90:00", wantMS: 5400000},
+		{position: "1:02:03", wantMS: 3723000},
+		{position: "90", wantMS: 90000},
+		{position: "90s", wantMS: 90000},
+		{position: "1m30s", wantMS: 90000},
+		{position: "+30s", wantRelative: true, wantMS: 30000},
+		{position: "-10s", wantRelative: true, wantMS: -10000},
+		{position: "+1:00", wantRelative: true, wantMS: 60000},
+		{position: "-15", wantRelative: true, wantMS: -15000},
+		{position: "1:30xyz", wantErr: true},
+		{position: "1:60", wantErr: true},
+		{position: "1:2:3:4", wantErr: true},
+		{position: "1:+5", wantErr: true},
+		{position: "1:", wantErr: true},
+		{position: "30xyz", wantErr: true},
+		{position: "+-30s", wantErr: true},
+		{position: "", wantErr: true},
+		{position: "+", wantErr: true},
+	}
+	for _, tt := range tests {
+		relative, ms, err := parseSeekPosition(tt.position)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseSeekPosition(%q) = %v, %d, want an error", tt.position, relative, ms)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSeekPosition(%q): %v", tt.position, err)
+			continue
+		}
+		if relative != tt.wantRelative || ms != tt.wantMS {
+			t.Errorf("parseSeekPosition(%q) = %v, %d, want %v, %d", tt.position, relative, ms, tt.wantRelative, tt.wantMS)
+		}
+	}
+}
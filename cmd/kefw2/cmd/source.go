@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/hilli/go-kef-w2/kefw2"
 	"github.com/spf13/cobra"
@@ -17,19 +16,35 @@ var sourceCmd = &cobra.Command{
 	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) != 1 {
-			source, _ := currentSpeaker.Source()
+			if getOnSpeakers(cmd, "source", func(speaker *kefw2.KEFSpeaker) (any, string, error) {
+				source, err := speaker.Source()
+				return source, fmt.Sprintf("Source is: %s", source.String()), err
+			}) {
+				return
+			}
+			requireSpeaker()
+			source, err := currentSpeaker.Source()
+			if err != nil {
+				exitWithError(err)
+			}
+			if outputJSON(cmd) {
+				printJSON(map[string]kefw2.Source{"source": source})
+				return
+			}
 			fmt.Printf("Source is: %s\n", source.String())
 			return
 		}
 		source, err := parseSource(args[0])
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		}
+		if runOnSpeakers(cmd, func(speaker *kefw2.KEFSpeaker) error { return speaker.SetSource(source) }) {
+			return
+		}
+		requireSpeaker()
 		err = currentSpeaker.SetSource(source)
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 	},
 	ValidArgsFunction: SourceCompletion,
@@ -37,17 +52,18 @@ var sourceCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(sourceCmd)
+	addMultiSpeakerFlags(sourceCmd)
 }
 
 func parseSource(source string) (kefw2.Source, error) {
 	switch source {
 	case "analog", "aux":
 		return kefw2.SourceAux, nil
-	case "bluetooth":
+	case "bluetooth", "bt":
 		return kefw2.SourceBluetooth, nil
-	case "coaxial":
+	case "coaxial", "coax":
 		return kefw2.SourceCoaxial, nil
-	case "optical":
+	case "optical", "opt":
 		return kefw2.SourceOptical, nil
 	case "tv":
 		return kefw2.SourceTV, nil
@@ -58,10 +74,19 @@ func parseSource(source string) (kefw2.Source, error) {
 	case "standby":
 		return kefw2.SourceStandby, nil
 	default:
-		return "", fmt.Errorf("source must be one of: analog, aux, bluetooth, coaxial, optical, tv, usb, wifi, standby")
+		return "", fmt.Errorf("source must be one of: analog (aux), bluetooth (bt), coaxial (coax), optical (opt), tv, usb, wifi, standby")
 	}
 }
 
 func SourceCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	return []string{"analog", "aux", "bluetooth", "coaxial", "optical", "tv", "usb", "wifi", "standby"}, cobra.ShellCompDirectiveNoFileComp
+	return []string{
+		"analog\tAnalog input (alias: aux)",
+		"bluetooth\tBluetooth (alias: bt)",
+		"coaxial\tCoaxial digital input (alias: coax)",
+		"optical\tOptical digital input (alias: opt)",
+		"tv\tHDMI eARC TV input",
+		"usb\tUSB input",
+		"wifi\tStreaming over the network",
+		"standby\tPut the speakers in standby",
+	}, cobra.ShellCompDirectiveNoFileComp
 }
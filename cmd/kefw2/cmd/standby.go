@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// standbyCmd gets or sets the auto standby timeout of the speakers
+var standbyCmd = &cobra.Command{
+	Use:   "standby",
+	Short: "Get or change when the speakers go to standby",
+	Long:  `Get or change how long the speakers wait without audio before going to standby`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		if len(args) != 1 {
+			standbyMode, err := currentSpeaker.GetStandbyMode()
+			if err != nil {
+				exitWithError(err)
+			}
+			if outputJSON(cmd) {
+				printJSON(map[string]string{"standby_mode": standbyModeName(standbyMode)})
+				return
+			}
+			fmt.Printf("Standby mode is: %s\n", standbyModeName(standbyMode))
+			return
+		}
+		standbyMode, err := parseStandbyMode(args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+		err = currentSpeaker.SetStandbyMode(standbyMode)
+		if err != nil {
+			exitWithError(err)
+		}
+	},
+	ValidArgsFunction: StandbyModeCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(standbyCmd)
+}
+
+func parseStandbyMode(standbyMode string) (kefw2.StandbyMode, error) {
+	switch standbyMode {
+	case "20m", "20", "standby_20mins":
+		return kefw2.StandbyMode20Mins, nil
+	case "30m", "30", "standby_30mins":
+		return kefw2.StandbyMode30Mins, nil
+	case "60m", "60", "standby_60mins":
+		return kefw2.StandbyMode60Mins, nil
+	case "never", "none", "standby_none":
+		return kefw2.StandbyModeNever, nil
+	default:
+		return "", fmt.Errorf("standby mode must be one of: 20m, 30m, 60m, never")
+	}
+}
+
+func standbyModeName(standbyMode kefw2.StandbyMode) string {
+	switch standbyMode {
+	case kefw2.StandbyMode20Mins:
+		return "20m"
+	case kefw2.StandbyMode30Mins:
+		return "30m"
+	case kefw2.StandbyMode60Mins:
+		return "60m"
+	case kefw2.StandbyModeNever:
+		return "never"
+	default:
+		return standbyMode.String()
+	}
+}
+
+func StandbyModeCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"20m", "30m", "60m", "never"}, cobra.ShellCompDirectiveNoFileComp
+}
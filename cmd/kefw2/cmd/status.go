@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"image"
 	_ "image/jpeg"
 	_ "image/png"
+	"io"
 	"net/http"
 	"os"
+	"os/signal"
+	"time"
 
+	"github.com/hilli/go-kef-w2/kefw2"
 	"github.com/qeesung/image2ascii/convert"
 	"github.com/spf13/cobra"
 )
@@ -15,29 +20,42 @@ import (
 // volumeCmd represents the volume command
 var statusCmd = &cobra.Command{
 	Use:     "status",
-	Aliases: []string{"info", "state", "st"},
+	Aliases: []string{"state", "st"},
 	Short:   "Status of the speakers",
 	Long:    `Status of the speakers`,
 	Args:    cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			interval, _ := cmd.Flags().GetDuration("interval")
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			watchStatus(ctx, interval)
+			return
+		}
+		if outputJSON(cmd) {
+			status, err := getStatusJSON()
+			if err != nil {
+				exitWithError(err)
+			}
+			printJSON(status)
+			return
+		}
 		source, err := currentSpeaker.Source()
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 		canControlPlayback, err := currentSpeaker.CanControlPlayback()
 		if err != nil {
-			fmt.Printf("Can't query source: %s\n", err.Error())
-			os.Exit(1)
+			exitWithError(fmt.Errorf("can't query source: %w", err))
 		}
 		if canControlPlayback {
 			pd, err := currentSpeaker.PlayerData()
 			if err != nil {
-				fmt.Println(err)
-				os.Exit(1)
+				exitWithError(err)
 			}
 			if playstate, err := currentSpeaker.IsPlaying(); err != nil {
-				fmt.Println("error getting playstate:", err)
+				fmt.Fprintln(os.Stderr, "error getting playstate:", err)
 			} else {
 				if playstate {
 					playTime, _ := currentSpeaker.SongProgress()
@@ -66,9 +84,94 @@ var statusCmd = &cobra.Command{
 	},
 }
 
+// statusArtCmd prints or downloads the album art of the playing track
+var statusArtCmd = &cobra.Command{
+	Use:   "art",
+	Short: "Album art of the playing track",
+	Long:  `Print the URL of the album art of the playing track, or save it to a file with --download`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		artURL, err := currentSpeaker.CurrentArtworkURL()
+		if err != nil {
+			exitWithError(err)
+		}
+		download, _ := cmd.Flags().GetString("download")
+		if download == "" {
+			fmt.Println(artURL)
+			return
+		}
+		if err := downloadFile(artURL, download); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(statusCmd)
+	statusCmd.AddCommand(statusArtCmd)
+	statusArtCmd.Flags().StringP("download", "d", "", "Save the album art to this file")
 	statusCmd.PersistentFlags().BoolP("minimal", "m", false, "Minimalistic output")
+	statusCmd.Flags().BoolP("json", "j", false, "Output as JSON")
+	statusCmd.Flags().BoolP("watch", "w", false, "Keep updating the status until interrupted")
+	statusCmd.Flags().Duration("interval", time.Second, "Update interval for --watch")
+}
+
+// watchStatus redraws the track, progress and volume every interval until ctx is cancelled
+func watchStatus(ctx context.Context, interval time.Duration) {
+	updates, errs := currentSpeaker.WatchPlayerData(ctx, interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var pd kefw2.PlayerData
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case p, ok := <-updates:
+			if !ok {
+				return
+			}
+			pd = p
+			lastErr = nil
+		case err, ok := <-errs:
+			if !ok {
+				return
+			}
+			lastErr = err
+		case <-ticker.C:
+		}
+		// Clear the screen and redraw from the top
+		fmt.Print("\033[H\033[2J")
+		if lastErr != nil {
+			fmt.Println("Error:", lastErr)
+			continue
+		}
+		volume, _ := currentSpeaker.GetVolume()
+		fmt.Printf("Volume: %d%%\n", volume)
+		if pd.State != "playing" {
+			fmt.Println("Audio Transport:", pd.State)
+			continue
+		}
+		if pd.IsLive() {
+			// The track of a radio station changes with the now playing info it sends
+			title, artist := pd.StreamMetadata()
+			fmt.Println("Station:", pd.MediaRoles.Title)
+			fmt.Println("Artist:", artist)
+			fmt.Println("Track:", title)
+			continue
+		}
+		playTime, _ := currentSpeaker.SongProgress()
+		fmt.Println("Audio Transport:", pd.MediaRoles.Title)
+		fmt.Println("Artist:", pd.TrackRoles.MediaData.MetaData.Artist)
+		fmt.Println("Album:", pd.TrackRoles.MediaData.MetaData.Album)
+		fmt.Println("Track:", pd.TrackRoles.Title)
+		if pd.Status.Duration == 0 {
+			fmt.Printf("Duration: %s\n", playTime)
+		} else {
+			fmt.Printf("Duration: %s/%s\n", playTime, pd.Status)
+		}
+	}
 }
 
 func imageArt2ASCII(imageURL string) string {
@@ -88,7 +191,7 @@ func imageArt2ASCII(imageURL string) string {
 	// Fetch image from URL into an image instance
 	artImage, err := fetchImageFromURL(imageURL)
 	if err != nil {
-		fmt.Println("Error fetching image:", err)
+		fmt.Fprintln(os.Stderr, "Error fetching image:", err)
 		return ""
 	}
 
@@ -111,3 +214,25 @@ func fetchImageFromURL(imageURL string) (image.Image, error) {
 
 	return img, nil
 }
+
+// downloadFile saves the contents of fileURL to path
+func downloadFile(fileURL, path string) error {
+	resp, err := http.Get(fileURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error downloading %s: %s", fileURL, resp.Status)
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// subCmd groups the subwoofer settings commands
+var subCmd = &cobra.Command{
+	Use:     "sub",
+	Aliases: []string{"subwoofer"},
+	Short:   "Get or adjust the subwoofer settings of the speakers",
+	Long: `Get or adjust the subwoofer output settings (level, crossover, polarity, high pass) of the speakers.
+Subwoofer settings are part of the EQ profile of the current source.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var subGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get the subwoofer settings of the speakers",
+	Long:  `Get the subwoofer settings of the speakers`,
+	Args:  cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		sub, err := currentSpeaker.GetSubwooferSettings()
+		if err != nil {
+			exitWithError(err)
+		}
+		if !sub.Connected() && !outputJSON(cmd) {
+			fmt.Println("No subwoofer is set up on the speakers.")
+		}
+		fmt.Println(sub)
+	},
+}
+
+var subSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Adjust the subwoofer settings of the speakers",
+	Long:  `Adjust the subwoofer settings of the speakers. Only the given flags are changed.`,
+	Args:  cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		sub, err := currentSpeaker.GetSubwooferSettings()
+		if err != nil {
+			exitWithError(err)
+		}
+		flags := cmd.Flags()
+		if flags.Changed("enable") {
+			sub.SubwooferOut, _ = flags.GetBool("enable")
+		}
+		if flags.Changed("count") {
+			sub.SubwooferCount, _ = flags.GetInt("count")
+		}
+		if flags.Changed("gain") {
+			sub.SubwooferGain, _ = flags.GetInt("gain")
+		}
+		if flags.Changed("crossover") {
+			sub.SubOutLPFreq, _ = flags.GetFloat32("crossover")
+		}
+		if flags.Changed("polarity") {
+			sub.SubwooferPolarity, _ = flags.GetString("polarity")
+		}
+		if flags.Changed("stereo") {
+			sub.SubEnableStereo, _ = flags.GetBool("stereo")
+		}
+		if flags.Changed("high-pass") {
+			sub.HighPassMode, _ = flags.GetBool("high-pass")
+		}
+		if flags.Changed("high-pass-freq") {
+			sub.HighPassModeFreq, _ = flags.GetInt("high-pass-freq")
+		}
+		if !sub.Connected() && !flags.Changed("enable") {
+			fmt.Println("Warning: no subwoofer is set up on the speakers. Enable it with --enable --count 1")
+		}
+		err = currentSpeaker.SetSubwooferSettings(sub)
+		if err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(subCmd)
+	subCmd.AddCommand(subGetCmd, subSetCmd)
+	subSetCmd.Flags().Bool("enable", false, "Enable the subwoofer output")
+	subSetCmd.Flags().Int("count", 1, "Number of subwoofers (0, 1, 2)")
+	subSetCmd.Flags().Int("gain", 0, "Subwoofer level in dB (-10 to 10)")
+	subSetCmd.Flags().Float32("crossover", 80, "Subwoofer crossover frequency in Hz (40 to 250)")
+	subSetCmd.Flags().String("polarity", "normal", "Subwoofer polarity (normal, inverted)")
+	subSetCmd.Flags().Bool("stereo", false, "Stereo subwoofer output")
+	subSetCmd.Flags().Bool("high-pass", false, "Enable the high pass filter on the speakers")
+	subSetCmd.Flags().Int("high-pass-freq", 0, "High pass filter frequency in Hz (50 to 120)")
+	subSetCmd.RegisterFlagCompletionFunc("polarity", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return kefw2.SubwooferPolarities, cobra.ShellCompDirectiveNoFileComp
+	})
+}
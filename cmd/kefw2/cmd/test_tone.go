@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/hilli/go-kef-w2/kefw2"
+	"github.com/spf13/cobra"
+)
+
+// testToneCmd identifies the left and right speaker of a stereo pair
+var testToneCmd = &cobra.Command{
+	Use:   "test-tone [left|right|both]",
+	Short: "Play on the left or right speaker only, to check the wiring",
+	Long: `Play on the left or right speaker only for --duration, to check the wiring of a stereo pair.
+The speakers have no test signal, so the balance is turned all the way to the channel and whatever is playing is heard there.
+The balance is restored afterwards, also when interrupted with Ctrl-C.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		requireSpeaker()
+		channel := kefw2.ChannelBoth
+		if len(args) == 1 {
+			channel = kefw2.Channel(args[0])
+		}
+		duration, _ := cmd.Flags().GetDuration("duration")
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		fmt.Printf("Playing on %s for %s\n", channel, duration)
+		if err := currentSpeaker.TestTone(ctx, channel, duration); err != nil && !errors.Is(err, context.Canceled) {
+			exitWithError(err)
+		}
+	},
+	ValidArgsFunction: ChannelCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(testToneCmd)
+	testToneCmd.Flags().Duration("duration", 3*time.Second, "How long to play on the channel")
+}
+
+func ChannelCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	channels := []string{}
+	for _, channel := range kefw2.Channels {
+		channels = append(channels, string(channel))
+	}
+	return channels, cobra.ShellCompDirectiveNoFileComp
+}
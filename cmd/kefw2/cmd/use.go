@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// speakerEnvVar overrides the active speaker, ie. for a script or a single shell
+const speakerEnvVar = "KEFW2_SPEAKER"
+
+// useCmd sets the speaker commands operate on in the current terminal
+var useCmd = &cobra.Command{
+	Use:   "use [name or IP]",
+	Short: "Set the speaker to operate on in this terminal",
+	Long: `Set the speaker commands operate on in this terminal, until another is chosen with use or --speaker (-s).
+Other terminals keep using their own choice or the default speaker.
+The KEFW2_SPEAKER environment variable takes precedence over the speaker chosen here.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if clearActive, _ := cmd.Flags().GetBool("clear"); clearActive {
+			if err := setActiveSpeaker(""); err != nil {
+				exitWithError(err)
+			}
+			return
+		}
+		if len(args) != 1 {
+			if active := activeSpeaker(); active != "" {
+				fmt.Printf("Active speaker is: %s\n", active)
+			} else if defaultSpeaker != nil {
+				fmt.Printf("No active speaker, using the default speaker: %s (%s)\n", defaultSpeaker.Name, defaultSpeaker.IPAddress)
+			} else {
+				fmt.Println("No active or default speaker")
+			}
+			return
+		}
+		speaker, err := ResolveSpeaker(args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+		if err := setActiveSpeaker(speaker.IPAddress); err != nil {
+			exitWithError(err)
+		}
+		fmt.Printf("Using speaker: %s (%s)\n", speaker.Name, speaker.IPAddress)
+	},
+	ValidArgsFunction: ConfiguredSpeakersCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(useCmd)
+	useCmd.Flags().Bool("clear", false, "Go back to using the default speaker in this terminal")
+}
+
+// activeSpeaker returns the speaker chosen with KEFW2_SPEAKER, or with use or --speaker in this terminal
+func activeSpeaker() string {
+	if speaker := os.Getenv(speakerEnvVar); speaker != "" {
+		return speaker
+	}
+	return readActiveSpeakers()[terminalKey()]
+}
+
+// setActiveSpeaker records speaker as the active speaker of this terminal. An empty speaker clears it.
+// Terminals that have since been closed are dropped from the state file.
+func setActiveSpeaker(speaker string) error {
+	active := readActiveSpeakers()
+	for key := range active {
+		if !terminalOpen(key) {
+			delete(active, key)
+		}
+	}
+	if speaker == "" {
+		delete(active, terminalKey())
+	} else {
+		active[terminalKey()] = speaker
+	}
+	data, err := json.MarshalIndent(active, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(activeSpeakersFile(), data, 0644)
+}
+
+// readActiveSpeakers reads the active speaker of each terminal from the state file
+func readActiveSpeakers() map[string]string {
+	active := map[string]string{}
+	data, err := os.ReadFile(activeSpeakersFile())
+	if err != nil {
+		return active
+	}
+	if err := json.Unmarshal(data, &active); err != nil || active == nil {
+		return map[string]string{}
+	}
+	return active
+}
+
+// terminalKey identifies the terminal by the shell running kefw2
+func terminalKey() string {
+	return processKey(os.Getppid())
+}
+
+// processKey identifies a process by its id and start time, as process ids are reused
+// once a process has ended. The key is just the id where the start time isn't known.
+func processKey(pid int) string {
+	if started := processStartTime(pid); started != "" {
+		return fmt.Sprintf("%d@%s", pid, started)
+	}
+	return strconv.Itoa(pid)
+}
+
+// terminalOpen reports if the shell of a terminal key is still running, and isn't a new process with the same id
+func terminalOpen(key string) bool {
+	pidStr, _, _ := strings.Cut(key, "@")
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil || !processRunning(pid) {
+		return false
+	}
+	return processKey(pid) == key
+}
+
+// processStartTime returns when the process started, in a format only meant for comparing, or "" if unknown
+func processStartTime(pid int) string {
+	switch runtime.GOOS {
+	case "linux":
+		// The start time is field 22 of stat, counting from the pid. The command name in field 2 can contain spaces.
+		data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+		if err != nil {
+			return ""
+		}
+		end := bytes.LastIndexByte(data, ')')
+		if end < 0 {
+			return ""
+		}
+		fields := strings.Fields(string(data[end+1:]))
+		if len(fields) < 20 {
+			return ""
+		}
+		return fields[19]
+	case "windows":
+		return ""
+	default:
+		out, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+		if err != nil {
+			return ""
+		}
+		return strings.Join(strings.Fields(string(out)), "-")
+	}
+}
+
+// processRunning reports if the process with pid is still running
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// FindProcess only finds running processes on Windows, and signal 0 isn't supported there
+		return true
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, os.ErrPermission)
+}
+
+// activeSpeakersFile is the state file with the active speaker of each terminal
+func activeSpeakersFile() string {
+	return stateFile("active_speakers.json")
+}
+
+// stateFile is the path of the state file called name, next to the config file given with --config
+func stateFile(name string) string {
+	return filepath.Join(filepath.Dir(viper.ConfigFileUsed()), name)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// useTempConfig points the config file, and so the state files next to it, to a temporary directory
+func useTempConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	previous := viper.ConfigFileUsed()
+	viper.SetConfigFile(filepath.Join(dir, "kefw2.yaml"))
+	t.Cleanup(func() { viper.SetConfigFile(previous) })
+	return dir
+}
+
+func TestStateFileFollowsConfig(t *testing.T) {
+	dir := useTempConfig(t)
+	if got, want := activeSpeakersFile(), filepath.Join(dir, "active_speakers.json"); got != want {
+		t.Errorf("activeSpeakersFile() = %s, want %s", got, want)
+	}
+}
+
+func TestSetActiveSpeakerDropsClosedTerminals(t *testing.T) {
+	useTempConfig(t)
+	closed := strconv.Itoa(math.MaxInt32)
+	running := processKey(os.Getpid())
+	// The process id of a closed terminal, now used by another process
+	reused := strconv.Itoa(os.Getpid()) + "@1"
+	data, err := json.Marshal(map[string]string{closed: "10.0.0.1", running: "10.0.0.2", reused: "10.0.0.4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(activeSpeakersFile(), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := setActiveSpeaker("10.0.0.3"); err != nil {
+		t.Fatal(err)
+	}
+
+	active := readActiveSpeakers()
+	if _, ok := active[closed]; ok {
+		t.Errorf("closed terminal %s was kept: %v", closed, active)
+	}
+	if _, ok := active[reused]; ok && reused != running {
+		t.Errorf("terminal with a reused process id %s was kept: %v", reused, active)
+	}
+	if active[running] != "10.0.0.2" {
+		t.Errorf("running terminal %s = %q, want 10.0.0.2", running, active[running])
+	}
+	if active[terminalKey()] != "10.0.0.3" {
+		t.Errorf("this terminal = %q, want 10.0.0.3", active[terminalKey()])
+	}
+}
@@ -2,8 +2,8 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
+	"github.com/hilli/go-kef-w2/kefw2"
 	"github.com/spf13/cobra"
 )
 
@@ -16,26 +16,90 @@ var volumeCmd = &cobra.Command{
 	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) != 1 {
-			volume, _ := currentSpeaker.GetVolume()
+			if getOnSpeakers(cmd, "volume", func(speaker *kefw2.KEFSpeaker) (any, string, error) {
+				volume, err := speaker.GetVolume()
+				return volume, fmt.Sprintf("Volume is: %d%%", volume), err
+			}) {
+				return
+			}
+			requireSpeaker()
+			volume, err := currentSpeaker.GetVolume()
+			if err != nil {
+				exitWithError(err)
+			}
+			if outputJSON(cmd) {
+				printJSON(map[string]int{"volume": volume})
+				return
+			}
 			fmt.Printf("Volume is: %d%%\n", volume)
 			return
 		}
 		volume, err := parseVolume(args[0])
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		}
+		if runOnSpeakers(cmd, func(speaker *kefw2.KEFSpeaker) error { return speaker.SetVolume(volume) }) {
+			return
+		}
+		requireSpeaker()
 		err = currentSpeaker.SetVolume(volume)
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 	},
 	ValidArgsFunction: VolumeCompletion,
 }
 
+var volumeUpCmd = &cobra.Command{
+	Use:   "up [step]",
+	Short: "Turn the volume up, 5% by default",
+	Long:  `Turn the volume up, 5% by default. The volume will not go above the max volume of the speakers`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		adjustVolume(cmd, args, 1)
+	},
+	ValidArgsFunction: VolumeStepCompletion,
+}
+
+var volumeDownCmd = &cobra.Command{
+	Use:   "down [step]",
+	Short: "Turn the volume down, 5% by default",
+	Long:  `Turn the volume down, 5% by default`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		adjustVolume(cmd, args, -1)
+	},
+	ValidArgsFunction: VolumeStepCompletion,
+}
+
 func init() {
 	rootCmd.AddCommand(volumeCmd)
+	volumeCmd.AddCommand(volumeUpCmd, volumeDownCmd)
+	addMultiSpeakerFlags(volumeCmd)
+}
+
+// adjustVolume changes the volume by the step given in args (default 5) in the given direction
+func adjustVolume(cmd *cobra.Command, args []string, direction int) {
+	step := 5
+	if len(args) == 1 {
+		var err error
+		step, err = parseVolume(args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+	}
+	if runOnSpeakers(cmd, func(speaker *kefw2.KEFSpeaker) error {
+		_, err := speaker.AdjustVolume(direction * step)
+		return err
+	}) {
+		return
+	}
+	requireSpeaker()
+	volume, err := currentSpeaker.AdjustVolume(direction * step)
+	if err != nil {
+		exitWithError(err)
+	}
+	fmt.Printf("Volume is: %d%%\n", volume)
 }
 
 func parseVolume(volume string) (int, error) {
@@ -53,3 +117,7 @@ func parseVolume(volume string) (int, error) {
 func VolumeCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	return []string{"0", "10", "20", "30", "40", "50", "60", "70", "80", "90", "100"}, cobra.ShellCompDirectiveNoFileComp
 }
+
+func VolumeStepCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"1", "2", "5", "10", "20"}, cobra.ShellCompDirectiveNoFileComp
+}
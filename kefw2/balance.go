@@ -0,0 +1,33 @@
+package kefw2
+
+import "fmt"
+
+const (
+	// MinBalance is the balance turned all the way to the left speaker
+	MinBalance = -30
+	// MaxBalance is the balance turned all the way to the right speaker
+	MaxBalance = 30
+)
+
+// GetBalance returns the left/right balance of the current source. 0 is centered,
+// negative values are towards the left speaker and positive towards the right.
+func (s *KEFSpeaker) GetBalance() (int, error) {
+	eqProfile, err := s.GetEQProfileV2()
+	if err != nil {
+		return 0, err
+	}
+	return eqProfile.Balance, nil
+}
+
+// SetBalance sets the left/right balance of the current source. The balance is part of the EQ profile.
+func (s *KEFSpeaker) SetBalance(balance int) error {
+	if balance < MinBalance || balance > MaxBalance {
+		return fmt.Errorf("balance must be between %d and %d, got %d", MinBalance, MaxBalance, balance)
+	}
+	eqProfile, err := s.GetEQProfileV2()
+	if err != nil {
+		return fmt.Errorf("failed to get EQ profile: %w", err)
+	}
+	eqProfile.Balance = balance
+	return s.SetEQProfileV2(eqProfile)
+}
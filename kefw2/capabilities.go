@@ -0,0 +1,33 @@
+package kefw2
+
+import "errors"
+
+// Capabilities summarizes what can be done with the speaker in its current state
+type Capabilities struct {
+	// PlaybackControl is set when the current source can be controlled, ie. wifi or bluetooth
+	PlaybackControl bool `json:"playback_control"`
+	// DSP is set when the EQ profile holding the DSP, balance and subwoofer settings can be read
+	DSP bool `json:"dsp"`
+	// Subwoofer is set when a subwoofer output is set up in the EQ profile
+	Subwoofer bool `json:"subwoofer"`
+}
+
+// Capabilities checks which features the speaker offers.
+// Only an unreachable speaker is an error, a feature that can't be read is reported as unavailable.
+func (s *KEFSpeaker) Capabilities() (Capabilities, error) {
+	var capabilities Capabilities
+	var err error
+	capabilities.PlaybackControl, err = s.CanControlPlayback()
+	if err != nil {
+		return Capabilities{}, err
+	}
+	eqProfile, err := s.GetEQProfileV2()
+	if errors.Is(err, ErrSpeakerUnreachable) {
+		return Capabilities{}, err
+	}
+	if err == nil {
+		capabilities.DSP = true
+		capabilities.Subwoofer = eqProfile.SubwooferOut && eqProfile.SubwooferCount > 0
+	}
+	return capabilities, nil
+}
@@ -0,0 +1,49 @@
+package kefw2
+
+import "testing"
+
+func TestCapabilities(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    Source
+		eqProfile map[string]any
+		want      Capabilities
+	}{
+		{
+			name:      "wifi with a subwoofer",
+			source:    SourceWiFi,
+			eqProfile: typedValue("kefEqProfileV2", map[string]any{"subwooferOut": true, "subwooferCount": 1}),
+			want:      Capabilities{PlaybackControl: true, DSP: true, Subwoofer: true},
+		},
+		{
+			name:      "tv without a subwoofer",
+			source:    SourceTV,
+			eqProfile: typedValue("kefEqProfileV2", map[string]any{"subwooferOut": false}),
+			want:      Capabilities{DSP: true},
+		},
+		{
+			name:   "no EQ profile",
+			source: SourceBluetooth,
+			want:   Capabilities{PlaybackControl: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]map[string]any{
+				"settings:/kef/play/physicalSource": typedValue("kefPhysicalSource", string(tt.source)),
+			}
+			if tt.eqProfile != nil {
+				values["kef:eqProfile/v2"] = tt.eqProfile
+			}
+			speaker := newTestSpeaker(t, newFakeSpeaker(values).ServeHTTP)
+
+			capabilities, err := speaker.Capabilities()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if capabilities != tt.want {
+				t.Errorf("Capabilities() = %+v, want %+v", capabilities, tt.want)
+			}
+		})
+	}
+}
@@ -2,36 +2,163 @@ package kefw2
 
 import (
 	"context"
+	"net"
+	"sync"
 	"time"
 
 	"github.com/brutella/dnssd"
 )
 
-func DiscoverSpeakers(timeout int) ([]KEFSpeaker, error) {
+// mdnsServices are the service types KEF speakers announce themselves with
+var mdnsServices = []string{"_kef._tcp.local.", "_http._tcp.local."}
+
+// DiscoverOption configures how discovered hosts are checked
+type DiscoverOption func(*discoverConfig)
+
+type discoverConfig struct {
+	workers     int
+	hostTimeout time.Duration
+}
+
+// WithWorkers sets how many hosts are checked at the same time. The default is 8.
+func WithWorkers(workers int) DiscoverOption {
+	return func(c *discoverConfig) {
+		c.workers = workers
+	}
+}
+
+// WithHostTimeout sets how long to wait for each host to answer. The default is 1 second.
+func WithHostTimeout(timeout time.Duration) DiscoverOption {
+	return func(c *discoverConfig) {
+		c.hostTimeout = timeout
+	}
+}
+
+func newDiscoverConfig(opts []DiscoverOption) discoverConfig {
+	config := discoverConfig{workers: 8, hostTimeout: 1 * time.Second}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	config.workers = max(config.workers, 1)
+	return config
+}
+
+// DiscoverSpeakersMDNS browses for KEF speakers with mDNS until ctx is done.
+// Every host found is checked with UpdateInfo, so only hosts answering like
+// a KEF W2 speaker are returned, once per IP address.
+func DiscoverSpeakersMDNS(ctx context.Context, opts ...DiscoverOption) ([]*KEFSpeaker, error) {
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	ips := []string{}
+	addFn := func(e dnssd.BrowseEntry) {
+		ip := entryIP(e)
+		mu.Lock()
+		defer mu.Unlock()
+		if ip != "" && !seen[ip] {
+			seen[ip] = true
+			ips = append(ips, ip)
+		}
+	}
+	rmvFn := func(e dnssd.BrowseEntry) {} // Empty, don't need it
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(mdnsServices))
+	for _, service := range mdnsServices {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := dnssd.LookupType(ctx, service, addFn, rmvFn); err != nil && ctx.Err() == nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	speakers := probeSpeakers(ips, newDiscoverConfig(opts))
+	if len(speakers) == 0 {
+		if err, ok := <-errs; ok {
+			return speakers, err
+		}
+	}
+	return speakers, nil
+}
+
+// entryIP returns the IPv4 address of a browse entry if it has one, otherwise the first address
+func entryIP(e dnssd.BrowseEntry) string {
+	var ip net.IP
+	for _, entryIP := range e.IPs {
+		if entryIP.To4() != nil {
+			return entryIP.String()
+		}
+		if ip == nil {
+			ip = entryIP
+		}
+	}
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+func DiscoverSpeakers(timeout int, opts ...DiscoverOption) ([]KEFSpeaker, error) {
 	discoveredSpeakers := []KEFSpeaker{}
 	ips, err := discoverIPs(timeout)
 	if err != nil {
 		return discoveredSpeakers, err
 	}
+	for _, speaker := range probeSpeakers(ips, newDiscoverConfig(opts)) {
+		discoveredSpeakers = append(discoveredSpeakers, *speaker)
+	}
+	return discoveredSpeakers, nil
+}
+
+// probeSpeakers checks the hosts concurrently and returns the ones that are KEF W2 speakers, in the order of ips.
+// A host must answer with its MAC address within the host timeout before the rest of UpdateInfo is tried.
+func probeSpeakers(ips []string, config discoverConfig) []*KEFSpeaker {
+	// Service Discovery may have the same speakers multiple times. Lets filter it down to single instance
+	seen := map[string]bool{}
+	candidates := []string{}
 	for _, ip := range ips {
-		speaker, err := NewSpeaker(ip)
-		if err != nil {
-			continue
+		if !seen[ip] {
+			seen[ip] = true
+			candidates = append(candidates, ip)
 		}
-		discoveredSpeakers = append(discoveredSpeakers, speaker)
 	}
 
-	// Service Discovery may have the same speakers multiple times. Lets filter it down to single instance
-	found := map[string]KEFSpeaker{}
-	for _, s := range discoveredSpeakers {
-		found[s.IPAddress] = s
+	results := make([]*KEFSpeaker, len(candidates))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(config.workers, len(candidates)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				speaker := &KEFSpeaker{IPAddress: candidates[i]}
+				speaker.SetOptions(WithTimeout(config.hostTimeout))
+				if _, err := speaker.getMACAddress(); err != nil {
+					continue
+				}
+				if err := speaker.UpdateInfo(); err != nil {
+					continue
+				}
+				results[i] = speaker
+			}
+		}()
 	}
-	discoveredSpeakers = []KEFSpeaker{}
-	for _, s := range found {
-		discoveredSpeakers = append(discoveredSpeakers, s)
+	for i := range candidates {
+		jobs <- i
 	}
+	close(jobs)
+	wg.Wait()
 
-	return discoveredSpeakers, nil
+	speakers := []*KEFSpeaker{}
+	for _, speaker := range results {
+		if speaker != nil {
+			speakers = append(speakers, speaker)
+		}
+	}
+	return speakers
 }
 
 func discoverIPs(timeout int) ([]string, error) {
@@ -46,7 +173,9 @@ func discoverIPs(timeout int) ([]string, error) {
 	service := "_http._tcp.local."
 
 	addFn := func(e dnssd.BrowseEntry) {
-		ips = append(ips, e.IPs[0].String())
+		if ip := entryIP(e); ip != "" {
+			ips = append(ips, ip)
+		}
 	}
 
 	rmvFn := func(e dnssd.BrowseEntry) {} // Empty, don't need it
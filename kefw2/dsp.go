@@ -0,0 +1,91 @@
+package kefw2
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DSPSettings are the room placement and tone settings of the speaker.
+// They are stored in the EQ profile of the currently selected source.
+type DSPSettings struct {
+	DeskMode         bool    `json:"deskMode"`
+	DeskModeSetting  int     `json:"deskModeSetting"` // dB, -6 to 0
+	WallMode         bool    `json:"wallMode"`
+	WallModeSetting  float32 `json:"wallModeSetting"` // dB, -6 to 0
+	TrebleAmount     float32 `json:"trebleAmount"`    // dB, -3 to 3
+	BassExtension    string  `json:"bassExtension"`   // less, standard, more
+	PhaseCorrection  bool    `json:"phaseCorrection"`
+	HighPassMode     bool    `json:"highPassMode"`
+	HighPassModeFreq int     `json:"highPassModeFreq"` // Hz, 50 to 120
+}
+
+var BassExtensions = []string{"less", "standard", "more"}
+
+// GetDSPSettings returns the DSP settings for the current source
+func (s *KEFSpeaker) GetDSPSettings() (DSPSettings, error) {
+	eqProfile, err := s.GetEQProfileV2()
+	if err != nil {
+		return DSPSettings{}, err
+	}
+	return DSPSettings{
+		DeskMode:         eqProfile.DeskMode,
+		DeskModeSetting:  eqProfile.DeskModeSetting,
+		WallMode:         eqProfile.WallMode,
+		WallModeSetting:  eqProfile.WallModeSetting,
+		TrebleAmount:     eqProfile.TrebleAmount,
+		BassExtension:    eqProfile.BassExtension,
+		PhaseCorrection:  eqProfile.PhaseCorrection,
+		HighPassMode:     eqProfile.HighPassMode,
+		HighPassModeFreq: eqProfile.HighPassModeFreq,
+	}, nil
+}
+
+// SetDSPSettings validates the DSP settings and writes them to the EQ profile of the current source.
+// The rest of the EQ profile is left untouched.
+func (s *KEFSpeaker) SetDSPSettings(dsp DSPSettings) error {
+	if err := dsp.Validate(); err != nil {
+		return err
+	}
+	eqProfile, err := s.GetEQProfileV2()
+	if err != nil {
+		return fmt.Errorf("failed to get EQ profile: %w", err)
+	}
+	eqProfile.DeskMode = dsp.DeskMode
+	eqProfile.DeskModeSetting = dsp.DeskModeSetting
+	eqProfile.WallMode = dsp.WallMode
+	eqProfile.WallModeSetting = dsp.WallModeSetting
+	eqProfile.TrebleAmount = dsp.TrebleAmount
+	eqProfile.BassExtension = dsp.BassExtension
+	eqProfile.PhaseCorrection = dsp.PhaseCorrection
+	eqProfile.HighPassMode = dsp.HighPassMode
+	eqProfile.HighPassModeFreq = dsp.HighPassModeFreq
+	return s.SetEQProfileV2(eqProfile)
+}
+
+// Validate checks the DSP settings are within the ranges the speaker accepts
+func (d DSPSettings) Validate() error {
+	if d.DeskModeSetting < -6 || d.DeskModeSetting > 0 {
+		return fmt.Errorf("desk mode setting must be between -6 and 0 dB, got %d", d.DeskModeSetting)
+	}
+	if d.WallModeSetting < -6 || d.WallModeSetting > 0 {
+		return fmt.Errorf("wall mode setting must be between -6 and 0 dB, got %g", d.WallModeSetting)
+	}
+	if d.TrebleAmount < -3 || d.TrebleAmount > 3 {
+		return fmt.Errorf("treble trim must be between -3 and 3 dB, got %g", d.TrebleAmount)
+	}
+	if d.HighPassMode && (d.HighPassModeFreq < 50 || d.HighPassModeFreq > 120) {
+		return fmt.Errorf("high pass frequency must be between 50 and 120 Hz, got %d", d.HighPassModeFreq)
+	}
+	for _, b := range BassExtensions {
+		if d.BassExtension == b {
+			return nil
+		}
+	}
+	return fmt.Errorf("bass extension must be one of: less, standard, more")
+}
+
+// String dumps a json DSPSettings
+func (d DSPSettings) String() string {
+	settings, _ := json.MarshalIndent(d, "", "  ")
+	return string(settings)
+}
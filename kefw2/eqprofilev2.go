@@ -31,8 +31,14 @@ type EQProfileV2 struct {
 // GetEQProfileV2 returns the current EQProfileV2 for the speaker
 // EQ Profiles are connected to the selected source
 func (s *KEFSpeaker) GetEQProfileV2() (EQProfileV2, error) {
-	eqProfile, err := JSONUnmarshalValue(s.getData("kef:eqProfile/v2"))
-	return eqProfile.(EQProfileV2), err
+	value, err := JSONUnmarshalValue(s.getData("kef:eqProfile/v2"))
+	eqProfile, _ := value.(EQProfileV2)
+	return eqProfile, err
+}
+
+// SetEQProfileV2 writes the EQProfileV2 for the current source to the speaker
+func (s *KEFSpeaker) SetEQProfileV2(eqProfile EQProfileV2) error {
+	return s.setTypedValue("kef:eqProfile/v2", eqProfile)
 }
 
 // String dumps a json EQProfileV2
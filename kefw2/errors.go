@@ -0,0 +1,26 @@
+package kefw2
+
+import "errors"
+
+var (
+	// ErrSpeakerUnreachable is matched by errors where the speaker didn't answer at all,
+	// ie. it is turned off at the wall, on another network or the address is wrong.
+	ErrSpeakerUnreachable = errors.New("speaker unreachable")
+	// ErrSourceCannotControl is matched by errors from playback controls used while
+	// the speaker is on a source where playback can't be controlled, ie. TV or optical.
+	ErrSourceCannotControl = errors.New("playback can't be controlled on the current source")
+)
+
+// sentinelError matches sentinel with errors.Is while keeping the message of err
+type sentinelError struct {
+	sentinel error
+	err      error
+}
+
+func (e sentinelError) Error() string {
+	return e.err.Error()
+}
+
+func (e sentinelError) Unwrap() []error {
+	return []error{e.sentinel, e.err}
+}
@@ -0,0 +1,51 @@
+package kefw2
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// FirmwareVersionUnknown is the available version reported when the upgrade info of the speaker can't be read as a version
+const FirmwareVersionUnknown = "unknown"
+
+// GetFirmwareUpdateStatus reports the installed firmware version from settings:/releasetext and the
+// version offered by kef:fwupgrade/info, which the speaker refreshes by itself (every 5 seconds while polled).
+// The upgrade info is read like the other string values of the speaker. If it holds something else,
+// available is FirmwareVersionUnknown rather than a guess. updateReady is only set for a known version
+// that differs from the installed one.
+// This only reports the status, the update is installed from the KEF Connect app.
+func (s *KEFSpeaker) GetFirmwareUpdateStatus(ctx context.Context) (current, available string, updateReady bool, err error) {
+	_, current, err = parseReleaseText(JSONStringValue(s.getDataContext(ctx, "settings:/releasetext")))
+	if err != nil {
+		return "", "", false, err
+	}
+	data, err := s.getDataContext(ctx, "kef:fwupgrade/info")
+	if err != nil {
+		return current, "", false, err
+	}
+	available, err = parseFirmwareUpgradeInfo(data)
+	if err != nil {
+		return current, "", false, err
+	}
+	return current, available, available != FirmwareVersionUnknown && available != current, nil
+}
+
+// parseFirmwareUpgradeInfo returns the version in the kef:fwupgrade/info value.
+// A release text like "LSXII_V27000" gives its version part, like settings:/releasetext.
+func parseFirmwareUpgradeInfo(data []byte) (string, error) {
+	var jsonData []map[string]any
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return "", err
+	}
+	if len(jsonData) == 0 {
+		return "", errEmptyResponse
+	}
+	version, ok := jsonData[0]["string_"].(string)
+	if !ok || version == "" {
+		return FirmwareVersionUnknown, nil
+	}
+	if _, v, err := parseReleaseText(version, nil); err == nil {
+		return v, nil
+	}
+	return version, nil
+}
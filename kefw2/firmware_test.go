@@ -0,0 +1,82 @@
+package kefw2
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetFirmwareUpdateStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		upgradeInfo   map[string]any
+		wantAvailable string
+		wantReady     bool
+	}{
+		{
+			name:          "newer version",
+			upgradeInfo:   typedValue("string_", "LSXII_V27000"),
+			wantAvailable: "V27000",
+			wantReady:     true,
+		},
+		{
+			name:          "installed version",
+			upgradeInfo:   typedValue("string_", "LSXII_V26120"),
+			wantAvailable: "V26120",
+		},
+		{
+			name:          "plain version",
+			upgradeInfo:   typedValue("string_", "V27000"),
+			wantAvailable: "V27000",
+			wantReady:     true,
+		},
+		{
+			name:          "not a version",
+			upgradeInfo:   typedValue("kefFwUpgradeInfo", map[string]any{"version": "LSXII_V27000"}),
+			wantAvailable: FirmwareVersionUnknown,
+		},
+		{
+			name:          "empty version",
+			upgradeInfo:   typedValue("string_", ""),
+			wantAvailable: FirmwareVersionUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeSpeaker(map[string]map[string]any{
+				"settings:/releasetext": typedValue("string_", "LSXII_V26120"),
+				"kef:fwupgrade/info":    tt.upgradeInfo,
+			})
+			speaker := newTestSpeaker(t, fake.ServeHTTP)
+			speaker.FirmwareVersion = "V1"
+
+			current, available, ready, err := speaker.GetFirmwareUpdateStatus(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if current != "V26120" {
+				t.Errorf("current = %q, want V26120", current)
+			}
+			if available != tt.wantAvailable || ready != tt.wantReady {
+				t.Errorf("available, ready = %q, %v, want %q, %v", available, ready, tt.wantAvailable, tt.wantReady)
+			}
+			if speaker.FirmwareVersion != "V1" {
+				t.Errorf("FirmwareVersion changed to %q", speaker.FirmwareVersion)
+			}
+		})
+	}
+}
+
+func TestGetFirmwareUpdateStatusUnknownPath(t *testing.T) {
+	fake := newFakeSpeaker(map[string]map[string]any{
+		"settings:/releasetext": typedValue("string_", "LSXII_V26120"),
+	})
+	speaker := newTestSpeaker(t, fake.ServeHTTP)
+
+	current, _, ready, err := speaker.GetFirmwareUpdateStatus(context.Background())
+	if err == nil {
+		t.Fatal("expected an error when the speaker has no upgrade info")
+	}
+	if current != "V26120" || ready {
+		t.Errorf("current, ready = %q, %v, want V26120, false", current, ready)
+	}
+}
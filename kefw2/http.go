@@ -2,6 +2,7 @@ package kefw2
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -18,11 +19,13 @@ type KEFPostRequest struct {
 }
 
 func (s KEFSpeaker) getData(path string) ([]byte, error) {
-	// log.SetLevel(log.DebugLevel)
-	client := &http.Client{}
-	client.Timeout = 1.0 * time.Second
+	return s.getDataContext(context.Background(), path)
+}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/api/getData", s.IPAddress), nil)
+// getDataContext is getData, giving up when ctx is done
+func (s KEFSpeaker) getDataContext(ctx context.Context, path string) ([]byte, error) {
+	// log.SetLevel(log.DebugLevel)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("http://%s/api/getData", s.IPAddress), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -34,30 +37,11 @@ func (s KEFSpeaker) getData(path string) ([]byte, error) {
 	q.Add("roles", "value")
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != 200 {
-		log.Debug("Response:", resp.StatusCode, resp.Body)
-		return nil, fmt.Errorf("HTTP Status Code: %d\n%s", resp.StatusCode, resp.Body)
-	}
-
-	return body, nil
+	return s.do(req, true)
 }
 
 func (s KEFSpeaker) getAllData(path string) ([]byte, error) {
 	// log.SetLevel(log.DebugLevel)
-	client := &http.Client{}
-	client.Timeout = 1.0 * time.Second
-
 	req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/api/getData", s.IPAddress), nil)
 	if err != nil {
 		return nil, err
@@ -70,29 +54,10 @@ func (s KEFSpeaker) getAllData(path string) ([]byte, error) {
 	q.Add("roles", "@all")
 	req.URL.RawQuery = q.Encode()
 	fmt.Println("Request URL:", req.URL.String())
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != 200 {
-		log.Debug("Response:", resp.StatusCode, resp.Body)
-		return nil, fmt.Errorf("HTTP Status Code: %d\n%s", resp.StatusCode, resp.Body)
-	}
-
-	return body, nil
+	return s.do(req, true)
 }
 
 func (s KEFSpeaker) getRows(path string, params map[string]string) ([]byte, error) {
-	client := &http.Client{}
-	client.Timeout = 1.0 * time.Second
-
 	req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/api/getRows", s.IPAddress), nil)
 	if err != nil {
 		return nil, err
@@ -107,33 +72,21 @@ func (s KEFSpeaker) getRows(path string, params map[string]string) ([]byte, erro
 	}
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		log.Debug("Response:", resp.StatusCode, resp.Body)
-		return nil, fmt.Errorf("HTTP Status Code: %d\n%s", resp.StatusCode, resp.Body)
-	}
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	return body, nil
+	return s.do(req, true)
 }
 
 func (s KEFSpeaker) setActivate(path, item, value string) error {
-	client := &http.Client{}
-	client.Timeout = 1.0 * time.Second
+	return s.setActivateValues(path, map[string]any{item: value})
+}
 
-	jsonStr, _ := json.Marshal(
-		map[string]string{
-			item: value,
-		})
+// setActivateValues activates path with several values, ie. a control with arguments
+func (s KEFSpeaker) setActivateValues(path string, values map[string]any) error {
+	return s.setActivateValuesContext(context.Background(), path, values)
+}
+
+// setActivateValuesContext is setActivateValues, giving up when ctx is done
+func (s KEFSpeaker) setActivateValuesContext(ctx context.Context, path string, values map[string]any) error {
+	jsonStr, _ := json.Marshal(values)
 	rawValue := json.RawMessage(jsonStr)
 
 	reqbody, _ := json.Marshal(KEFPostRequest{
@@ -142,38 +95,21 @@ func (s KEFSpeaker) setActivate(path, item, value string) error {
 		Value: &rawValue,
 	})
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/api/setData", s.IPAddress), bytes.NewBuffer(reqbody))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("http://%s/api/setData", s.IPAddress), bytes.NewBuffer(reqbody))
 	if err != nil {
 		return err
 	}
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		log.Debug("Response:", resp.StatusCode, resp.Body)
-		return fmt.Errorf("HTTP Status Code: %d\n%s", resp.StatusCode, resp.Body)
-	}
-
-	// body, err := ioutil.ReadAll(resp.Body)
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	return nil
+	// Control actions, like pause, change state each time, so they are retried at most once
+	_, err = s.do(req, false)
+	return err
 }
 
 func (s KEFSpeaker) setTypedValue(path string, value any) error {
-	client := &http.Client{}
-	client.Timeout = 1.0 * time.Second
-
 	var myType string
-	var myValue string
+	var myValue any
 	switch theType := value.(type) {
 	case int:
 		myType = "i32_"
@@ -193,13 +129,19 @@ func (s KEFSpeaker) setTypedValue(path string, value any) error {
 	case CableMode:
 		myType = "kefCableMode"
 		myValue = fmt.Sprintf("\"%s\"", value.(CableMode))
+	case StandbyMode:
+		myType = "kefStandbyMode"
+		myValue = string(value.(StandbyMode))
+	case EQProfileV2:
+		myType = "kefEqProfileV2"
+		myValue = value.(EQProfileV2)
 	default:
 		return fmt.Errorf("type %s is not supported", theType)
 	}
 
 	// Build the JSON
 	jsonStr, _ := json.Marshal(
-		map[string]string{
+		map[string]any{
 			"type": myType,
 			myType: myValue,
 		})
@@ -218,21 +160,59 @@ func (s KEFSpeaker) setTypedValue(path string, value any) error {
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
 
+	// Setting a value twice gives the same result, so it can be retried like a GET
+	_, err = s.do(req, true)
+	return err
+}
+
+// do sends req and returns the body of a 200 OK response.
+// Connection errors and 5xx responses are retried as configured with WithRetry,
+// but requests that aren't idempotent are retried at most once.
+func (s KEFSpeaker) do(req *http.Request, idempotent bool) ([]byte, error) {
+	client := &http.Client{}
+	client.Timeout = 1.0 * time.Second
+	if s.timeout > 0 {
+		client.Timeout = s.timeout
+	}
+
+	attempts := max(s.retryAttempts, 1)
+	if !idempotent {
+		attempts = min(attempts, 2)
+	}
+	backoff := s.retryBackoff
+	for attempt := 1; ; attempt++ {
+		body, retryable, err := doOnce(client, req)
+		if err == nil || !retryable || attempt >= attempts {
+			return body, err
+		}
+		log.Debugf("Request to %s failed (attempt %d of %d), retrying in %s: %s", req.URL.Path, attempt, attempts, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// doOnce sends req once. retryable reports if a failure might go away by trying again.
+func doOnce(client *http.Client, req *http.Request) (body []byte, retryable bool, err error) {
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return nil, true, sentinelError{sentinel: ErrSpeakerUnreachable, err: err}
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		log.Debug("Response:", resp.StatusCode, resp.Body)
-		return fmt.Errorf("HTTP Status Code: %d\n%s", resp.StatusCode, resp.Body)
+	body, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
 	}
 
-	// body, err := ioutil.ReadAll(resp.Body)
-	// if err != nil {
-	// 	return nil, err
-	// }
+	if resp.StatusCode != 200 {
+		log.Debug("Response:", resp.StatusCode, string(body))
+		return nil, resp.StatusCode >= 500, fmt.Errorf("HTTP Status Code: %d\n%s", resp.StatusCode, body)
+	}
 
-	return nil
+	return body, false, nil
 }
@@ -0,0 +1,86 @@
+package kefw2
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyHandler fails the first failures requests with a 500 and answers the rest with body
+func flakyHandler(failures int32, body string, requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(body))
+	}
+}
+
+func TestRetryRead(t *testing.T) {
+	var requests atomic.Int32
+	speaker := newTestSpeaker(t, flakyHandler(2, `[{"type":"i32_","i32_":42}]`, &requests), WithRetry(3, time.Millisecond))
+
+	volume, err := speaker.GetVolume()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume != 42 {
+		t.Errorf("got volume %d, want 42", volume)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("got %d requests, want 3", requests.Load())
+	}
+}
+
+func TestRetryReadGivesUp(t *testing.T) {
+	var requests atomic.Int32
+	speaker := newTestSpeaker(t, flakyHandler(10, "", &requests), WithRetry(3, time.Millisecond))
+
+	if _, err := speaker.GetVolume(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if requests.Load() != 3 {
+		t.Errorf("got %d requests, want 3", requests.Load())
+	}
+}
+
+func TestNoRetryByDefault(t *testing.T) {
+	var requests atomic.Int32
+	speaker := newTestSpeaker(t, flakyHandler(1, `[{"type":"i32_","i32_":42}]`, &requests))
+
+	if _, err := speaker.GetVolume(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if requests.Load() != 1 {
+		t.Errorf("got %d requests, want 1", requests.Load())
+	}
+}
+
+func TestRetryControlAtMostOnce(t *testing.T) {
+	var requests atomic.Int32
+	speaker := newTestSpeaker(t, flakyHandler(10, "", &requests), WithRetry(5, time.Millisecond))
+
+	if err := speaker.setActivate("player:player/control", "control", "pause"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if requests.Load() != 2 {
+		t.Errorf("got %d requests, want 2", requests.Load())
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	var requests atomic.Int32
+	speaker := newTestSpeaker(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}, WithRetry(3, time.Millisecond))
+
+	if _, err := speaker.GetVolume(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if requests.Load() != 1 {
+		t.Errorf("got %d requests, want 1", requests.Load())
+	}
+}
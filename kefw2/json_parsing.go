@@ -5,6 +5,9 @@ import (
 	"errors"
 )
 
+// errEmptyResponse is returned when the speaker answers with no values at all
+var errEmptyResponse = errors.New("empty response from speaker")
+
 func JSONStringValue(data []byte, err error) (value string, err2 error) {
 	if err != nil {
 		return "", err
@@ -12,9 +15,15 @@ func JSONStringValue(data []byte, err error) (value string, err2 error) {
 	var jsonData []map[string]interface{}
 	err2 = json.Unmarshal(data, &jsonData)
 	if err2 != nil {
-		return "", err
+		return "", err2
+	}
+	if len(jsonData) == 0 {
+		return "", errEmptyResponse
+	}
+	value, ok := jsonData[0]["string_"].(string)
+	if !ok {
+		return "", errors.New("value is not a string")
 	}
-	value = jsonData[0]["string_"].(string)
 	return value, nil
 }
 
@@ -25,7 +34,10 @@ func JSONStringValueByKey(data []byte, key string, err error) (value string, err
 	var jsonData []map[string]string
 	err2 = json.Unmarshal(data, &jsonData)
 	if err2 != nil {
-		return "", err
+		return "", err2
+	}
+	if len(jsonData) == 0 {
+		return "", errEmptyResponse
 	}
 	value = jsonData[0]["value"]
 	return value, nil
@@ -38,7 +50,10 @@ func JSONIntValue(data []byte, err error) (value int, err2 error) {
 	var jsonData []map[string]interface{}
 	err2 = json.Unmarshal(data, &jsonData)
 	if err2 != nil {
-		return 0, err
+		return 0, err2
+	}
+	if len(jsonData) == 0 {
+		return 0, errEmptyResponse
 	}
 	jval := jsonData[0]["i32_"]
 	if jval == nil {
@@ -58,29 +73,41 @@ func JSONUnmarshalValue(data []byte, err error) (value any, err2 error) {
 	var jsonData []map[string]any
 	err2 = json.Unmarshal(data, &jsonData)
 	if err2 != nil {
-		return 0, err
+		return 0, err2
+	}
+	if len(jsonData) == 0 {
+		return nil, errEmptyResponse
 	}
-	// Locate the value and set the type
-	tvalue := jsonData[0]["type"].(string)
+	// Locate the value and set the type.
+	// Unexpected value types give the zero value rather than a panic.
+	tvalue, _ := jsonData[0]["type"].(string)
 	switch tvalue {
-	case "i32_":
-		value = jsonData[0]["i32_"].(int)
-	case "i64_":
-		value = jsonData[0]["i64_"].(int)
+	case "i32_", "i64_":
+		number, _ := jsonData[0][tvalue].(float64)
+		value = int(number)
 	case "string_":
-		value = jsonData[0]["string_"].(string)
+		value, _ = jsonData[0]["string_"].(string)
 	case "bool_":
-		if jsonData[0]["bool_"] == "false" {
-			value = false
-		} else {
+		switch b := jsonData[0]["bool_"].(type) {
+		case bool:
+			value = b
+		case string:
+			value = b != "false"
+		default:
 			value = true
 		}
 	case "kefPhysicalSource":
-		value = Source(jsonData[0]["kefPhysicalSource"].(string))
+		source, _ := jsonData[0]["kefPhysicalSource"].(string)
+		value = Source(source)
 	case "kefSpeakerStatus":
-		value = SpeakerStatus(jsonData[0]["kefSpeakerStatus"].(string))
+		status, _ := jsonData[0]["kefSpeakerStatus"].(string)
+		value = SpeakerStatus(status)
 	case "kefCableMode":
-		value = CableMode(jsonData[0]["kefCableMode"].(string))
+		cableMode, _ := jsonData[0]["kefCableMode"].(string)
+		value = CableMode(cableMode)
+	case "kefStandbyMode":
+		standbyMode, _ := jsonData[0]["kefStandbyMode"].(string)
+		value = StandbyMode(standbyMode)
 	case "kefEqProfileV2":
 		// Unmarshal the EQProfileV2 part of the JSON data.
 		// But turn the relevant part of the jsonData into json again first.
@@ -1,9 +1,15 @@
 package kefw2
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
 )
 
 type KEFSpeaker struct {
@@ -14,6 +20,10 @@ type KEFSpeaker struct {
 	MacAddress      string `mapstructure:"mac_address" json:"mac_address" yaml:"mac_address"`
 	Id              string `mapstructure:"id" json:"id" yaml:"id"`
 	MaxVolume       int    `mapstructure:"max_volume" json:"max_volume" yaml:"max_volume"`
+
+	timeout       time.Duration
+	retryAttempts int
+	retryBackoff  time.Duration
 }
 
 type KEFGrouping struct {
@@ -21,8 +31,8 @@ type KEFGrouping struct {
 }
 
 type KEFGroupingmember struct {
-	Master   KEFGroupingData `json:"master`
-	Follower KEFGroupingData `json:"folloer"`
+	Master   KEFGroupingData `json:"master"`
+	Follower KEFGroupingData `json:"follower"`
 }
 
 type KEFGroupingData struct {
@@ -39,13 +49,14 @@ var (
 	}
 )
 
-func NewSpeaker(IPAddress string) (KEFSpeaker, error) {
+func NewSpeaker(IPAddress string, opts ...SpeakerOption) (KEFSpeaker, error) {
 	if IPAddress == "" {
 		return KEFSpeaker{}, fmt.Errorf("KEF Speaker IP is empty")
 	}
 	speaker := KEFSpeaker{
 		IPAddress: IPAddress,
 	}
+	speaker.SetOptions(opts...)
 	err := speaker.UpdateInfo()
 	if err != nil {
 		return speaker, err
@@ -82,8 +93,9 @@ func (s *KEFSpeaker) getMACAddress() (string, error) {
 }
 
 func (s *KEFSpeaker) NetworkOperationMode() (CableMode, error) {
-	cableMode, err := JSONUnmarshalValue(s.getData("settings:/kef/host/cableMode"))
-	return cableMode.(CableMode), err
+	value, err := JSONUnmarshalValue(s.getData("settings:/kef/host/cableMode"))
+	cableMode, _ := value.(CableMode)
+	return cableMode, err
 }
 
 func (s *KEFSpeaker) getName() (string, error) {
@@ -91,6 +103,22 @@ func (s *KEFSpeaker) getName() (string, error) {
 }
 
 func (s *KEFSpeaker) getId() (err error) {
+	groupData, err := s.GetGrouping()
+	if err != nil {
+		return err
+	}
+	speakersets := groupData.GroupingMembers
+	for _, speakerset := range speakersets {
+		if speakerset.Master.Name == s.Name {
+			s.Id = speakerset.Master.Id
+		}
+	}
+	return nil
+}
+
+// GetGrouping returns the grouping members of the speaker, the master and follower
+// of a speaker pair. A speaker without a follower has an empty Follower.
+func (s *KEFSpeaker) GetGrouping() (KEFGrouping, error) {
 	params := map[string]string{
 		"roles": "@all",
 		"from":  "0",
@@ -98,31 +126,44 @@ func (s *KEFSpeaker) getId() (err error) {
 	}
 	data, err := s.getRows("grouping:members", params)
 	if err != nil {
-		return err
+		return KEFGrouping{}, err
 	}
 	groupData := KEFGrouping{}
-	err = json.Unmarshal(data, &groupData)
-	speakersets := groupData.GroupingMembers
-	for _, speakerset := range speakersets {
-		if speakerset.Master.Name == s.Name {
-			s.Id = speakerset.Master.Id
-		}
+	if err = json.Unmarshal(data, &groupData); err != nil {
+		return KEFGrouping{}, err
 	}
-	return err
+	return groupData, nil
 }
 
 func (s *KEFSpeaker) getModelAndVersion() error {
-	model, err := JSONStringValue(s.getData("settings:/releasetext"))
-	modelAndVersion := strings.Split(model, "_")
-	s.Model = Models[modelAndVersion[0]]
+	model, version, err := parseReleaseText(JSONStringValue(s.getData("settings:/releasetext")))
+	if err != nil {
+		return err
+	}
+	s.Model = Models[model]
 	if s.Model == "" {
-		s.Model = modelAndVersion[0]
+		s.Model = model
+	}
+	s.FirmwareVersion = version
+	return nil
+}
+
+// parseReleaseText splits a release text like "LSXII_V26120" into the model code and the firmware version
+func parseReleaseText(releaseText string, err error) (model, version string, err2 error) {
+	if err != nil {
+		return "", "", err
+	}
+	modelAndVersion := strings.SplitN(releaseText, "_", 2)
+	if len(modelAndVersion) != 2 {
+		return "", "", fmt.Errorf("unexpected release text: %q", releaseText)
 	}
-	s.FirmwareVersion = modelAndVersion[1]
-	return err
+	return modelAndVersion[0], modelAndVersion[1], nil
 }
 
 func (s KEFSpeaker) PlayPause() error {
+	if err := s.checkPlaybackControl(); err != nil {
+		return err
+	}
 	return s.setActivate("player:player/control", "control", "pause")
 }
 
@@ -135,6 +176,21 @@ func (s KEFSpeaker) SetVolume(volume int) error {
 	return s.setTypedValue(path, volume)
 }
 
+// AdjustVolume changes the volume relative to the current level, keeping it
+// between 0 and the max volume of the speaker. The new volume is returned.
+func (s *KEFSpeaker) AdjustVolume(delta int) (int, error) {
+	volume, err := s.GetVolume()
+	if err != nil {
+		return 0, err
+	}
+	maxVolume, err := s.GetMaxVolume()
+	if err != nil {
+		return volume, err
+	}
+	volume = min(max(volume+delta, 0), maxVolume)
+	return volume, s.SetVolume(volume)
+}
+
 func (s KEFSpeaker) Mute() error {
 	path := "settings:/mediaPlayer/mute"
 	return s.setTypedValue(path, true)
@@ -147,8 +203,98 @@ func (s KEFSpeaker) Unmute() error {
 
 func (s KEFSpeaker) IsMuted() (bool, error) {
 	path := "settings:/mediaPlayer/mute"
-	muted, err := JSONUnmarshalValue(s.getData(path))
-	return muted.(bool), err
+	value, err := JSONUnmarshalValue(s.getData(path))
+	muted, _ := value.(bool)
+	return muted, err
+}
+
+// PowerOn wakes the speaker from standby.
+// Speakers in network standby may not answer, so if the request times out
+// a Wake-on-LAN packet is sent and the request is retried while the speaker wakes up.
+func (s KEFSpeaker) PowerOn() error {
+	// The speaker is turned on by selecting "powerOn" as the physical source
+	err := s.SetSource(Source(SpeakerStatusOn))
+	var netErr net.Error
+	if err == nil || !errors.As(err, &netErr) || !netErr.Timeout() {
+		return err
+	}
+	log.Debug("Speaker did not answer, sending Wake-on-LAN packet to ", s.MacAddress)
+	if wolErr := s.WakeOnLAN(); wolErr != nil {
+		return fmt.Errorf("speaker did not answer (%w) and Wake-on-LAN failed: %s", err, wolErr)
+	}
+	for i := 0; i < 10; i++ {
+		time.Sleep(1 * time.Second)
+		if err = s.SetSource(Source(SpeakerStatusOn)); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("speaker did not wake up after Wake-on-LAN: %w", err)
+}
+
+// PowerOnWithVolume powers the speaker on muted and fades the volume from 0 up to target over rampDuration.
+// The target is limited to the max volume of the speaker.
+// The ramp stops early without an error if ctx is cancelled or the volume is changed by someone else meanwhile.
+func (s KEFSpeaker) PowerOnWithVolume(ctx context.Context, target int, rampDuration time.Duration) error {
+	if target < 0 || target > 100 {
+		return fmt.Errorf("volume must be between 0%% and 100%%")
+	}
+	quiet := func() error {
+		maxVolume, err := s.GetMaxVolume()
+		if err != nil {
+			return err
+		}
+		target = min(target, maxVolume)
+		if err := s.Mute(); err != nil {
+			return err
+		}
+		return s.SetVolume(0)
+	}
+	// Quiet the speaker while it is still in standby, so it doesn't wake up at its last volume.
+	// A speaker in network standby may not answer until it is woken, then it is quieted right after.
+	quieted := quiet() == nil
+	if err := s.PowerOn(); err != nil {
+		return err
+	}
+	if !quieted {
+		if err := quiet(); err != nil {
+			return err
+		}
+	}
+	if rampDuration <= 0 {
+		if err := s.SetVolume(target); err != nil {
+			return err
+		}
+		return s.Unmute()
+	}
+	if err := s.Unmute(); err != nil {
+		return err
+	}
+
+	// Step at most every 100ms, which is also about as fast as the speaker takes volume changes
+	steps := max(min(target, int(rampDuration/(100*time.Millisecond))), 1)
+	ticker := time.NewTicker(rampDuration / time.Duration(steps))
+	defer ticker.Stop()
+	volume := 0
+	for step := 1; step <= steps; step++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		current, err := s.GetVolume()
+		if err != nil {
+			return err
+		}
+		if current != volume {
+			log.Debug("Volume changed to ", current, " during the ramp, stopping it")
+			return nil
+		}
+		volume = target * step / steps
+		if err := s.SetVolume(volume); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // PowerOff set the speaker to standby mode
@@ -163,16 +309,31 @@ func (s KEFSpeaker) SetSource(source Source) error {
 
 func (s *KEFSpeaker) Source() (Source, error) {
 	data, err := s.getData("settings:/kef/play/physicalSource")
-	src, err2 := JSONUnmarshalValue(data, err)
-	return src.(Source), err2
+	value, err2 := JSONUnmarshalValue(data, err)
+	src, _ := value.(Source)
+	return src, err2
 }
 
+// CanControlPlayback reports if playback can be controlled on the current source, ie. wifi or bluetooth
 func (s *KEFSpeaker) CanControlPlayback() (bool, error) {
 	source, err := s.Source()
 	if err != nil {
 		return false, fmt.Errorf("failed getting speaker source: %w", err)
 	}
-	return (source != SourceWiFi || source != SourceBluetooth), nil
+	return source.CanControlPlayback(), nil
+}
+
+// checkPlaybackControl returns an error matching ErrSourceCannotControl
+// if playback can't be controlled on the current source
+func (s *KEFSpeaker) checkPlaybackControl() error {
+	source, err := s.Source()
+	if err != nil {
+		return fmt.Errorf("failed getting speaker source: %w", err)
+	}
+	if !source.CanControlPlayback() {
+		return fmt.Errorf("playback control unavailable on source '%s': %w", source, ErrSourceCannotControl)
+	}
+	return nil
 }
 
 func (s *KEFSpeaker) IsPoweredOn() (bool, error) {
@@ -184,8 +345,9 @@ func (s *KEFSpeaker) IsPoweredOn() (bool, error) {
 }
 
 func (s *KEFSpeaker) SpeakerState() (SpeakerStatus, error) {
-	speakerStatus, err := JSONUnmarshalValue(s.getData("settings:/kef/host/speakerStatus"))
-	return SpeakerStatus(speakerStatus.(SpeakerStatus)), err
+	value, err := JSONUnmarshalValue(s.getData("settings:/kef/host/speakerStatus"))
+	speakerStatus, _ := value.(SpeakerStatus)
+	return speakerStatus, err
 }
 
 func (s *KEFSpeaker) GetMaxVolume() (int, error) {
@@ -201,6 +363,18 @@ func (s *KEFSpeaker) SetMaxVolume(maxVolume int) error {
 	return s.setTypedValue(path, maxVolume)
 }
 
+// GetStandbyMode returns how long the speaker waits before going to standby
+func (s *KEFSpeaker) GetStandbyMode() (StandbyMode, error) {
+	value, err := JSONUnmarshalValue(s.getData("settings:/kef/host/standbyMode"))
+	standbyMode, _ := value.(StandbyMode)
+	return standbyMode, err
+}
+
+// SetStandbyMode sets how long the speaker waits before going to standby
+func (s *KEFSpeaker) SetStandbyMode(standbyMode StandbyMode) error {
+	return s.setTypedValue("settings:/kef/host/standbyMode", standbyMode)
+}
+
 func (s *KEFSpeaker) IsPlaying() (bool, error) {
 	pd, err := s.PlayerData()
 	if err != nil {
@@ -211,19 +385,79 @@ func (s *KEFSpeaker) IsPlaying() (bool, error) {
 
 // NextTrack works only if the speaker is playing in wifi mode
 func (s *KEFSpeaker) NextTrack() error {
+	if err := s.checkPlaybackControl(); err != nil {
+		return err
+	}
 	return s.setActivate("player:player/control", "control", "next")
 }
 
 // PreviousTrack works only if the speaker is playing in wifi mode
 func (s *KEFSpeaker) PreviousTrack() error {
+	if err := s.checkPlaybackControl(); err != nil {
+		return err
+	}
 	return s.setActivate("player:player/control", "control", "previous")
 }
 
+// Seek jumps to positionMS milliseconds into the current track.
+// Works only if the speaker is playing in wifi mode.
+func (s *KEFSpeaker) Seek(ctx context.Context, positionMS int) error {
+	if positionMS < 0 {
+		return fmt.Errorf("can't seek to a negative position")
+	}
+	if err := s.checkPlaybackControl(); err != nil {
+		return err
+	}
+	pd, err := s.playerData(ctx)
+	if err != nil {
+		return err
+	}
+	if pd.Status.Duration > 0 && positionMS > pd.Status.Duration {
+		return fmt.Errorf("can't seek to %s, the track is only %s long", PlayerResource{Duration: positionMS}, pd.Status)
+	}
+	return s.seekTime(ctx, positionMS)
+}
+
+// SeekForward jumps ms milliseconds ahead in the current track, stopping at the end of the track
+func (s *KEFSpeaker) SeekForward(ctx context.Context, ms int) error {
+	return s.seekRelative(ctx, ms)
+}
+
+// SeekBackward jumps ms milliseconds back in the current track, stopping at the start of the track
+func (s *KEFSpeaker) SeekBackward(ctx context.Context, ms int) error {
+	return s.seekRelative(ctx, -ms)
+}
+
+func (s *KEFSpeaker) seekRelative(ctx context.Context, deltaMS int) error {
+	if err := s.checkPlaybackControl(); err != nil {
+		return err
+	}
+	position, err := JSONIntValue(s.getDataContext(ctx, "player:player/data/playTime"))
+	if err != nil {
+		return err
+	}
+	pd, err := s.playerData(ctx)
+	if err != nil {
+		return err
+	}
+	position = max(position+deltaMS, 0)
+	if pd.Status.Duration > 0 {
+		position = min(position, pd.Status.Duration)
+	}
+	return s.seekTime(ctx, position)
+}
+
+func (s *KEFSpeaker) seekTime(ctx context.Context, positionMS int) error {
+	return s.setActivateValuesContext(ctx, "player:player/control", map[string]any{
+		"control": "seekTime",
+		"time":    positionMS,
+	})
+}
+
 // PlayerData returns the current song progress as a string: "minutes:seconds"
 func (s *KEFSpeaker) SongProgress() (string, error) {
 	playMs, err := s.SongProgressMS()
 	if err != nil {
-		fmt.Println("err", err)
 		return "0:00", err
 	}
 	playTime := fmt.Sprintf("%d:%02d", playMs/60000, (playMs/1000)%60)
@@ -236,7 +470,6 @@ func (s *KEFSpeaker) SongProgressMS() (int, error) {
 	data, err := s.getData(path)
 	playMS, err := JSONIntValue(data, err)
 	if err != nil {
-		fmt.Println("err", err)
 		return 0, err
 	}
 	return playMS, err
@@ -0,0 +1,116 @@
+package kefw2
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPowerOnWithVolumeQuietsBeforePowerOn(t *testing.T) {
+	fake := newFakeSpeaker(map[string]map[string]any{
+		"settings:/kef/host/maximumVolume":  typedValue("i32_", 15),
+		"player:volume":                     typedValue("i32_", 60),
+		"settings:/mediaPlayer/mute":        typedValue("bool_", false),
+		"settings:/kef/play/physicalSource": typedValue("kefPhysicalSource", "standby"),
+	})
+	speaker := newTestSpeaker(t, fake.ServeHTTP)
+
+	if err := speaker.PowerOnWithVolume(context.Background(), 20, 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	powerOn := fake.setIndex("settings:/kef/play/physicalSource=powerOn")
+	if powerOn < 0 {
+		t.Fatalf("speaker was not powered on: %v", fake.sets)
+	}
+	for _, set := range []string{"settings:/mediaPlayer/mute=true", "player:volume=0"} {
+		if i := fake.setIndex(set); i < 0 || i > powerOn {
+			t.Errorf("%s should be set before powering on: %v", set, fake.sets)
+		}
+	}
+	if i := fake.setIndex("settings:/mediaPlayer/mute=false"); i < powerOn {
+		t.Errorf("speaker should be unmuted after powering on: %v", fake.sets)
+	}
+	// The target is above the max volume, so the ramp ends at the max volume
+	volume, err := speaker.GetVolume()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume != 15 {
+		t.Errorf("volume is %d, want the max volume 15", volume)
+	}
+}
+
+func TestPowerOnWithVolumeRejectsInvalidVolume(t *testing.T) {
+	speaker := KEFSpeaker{}
+	for _, volume := range []int{-1, 101} {
+		if err := speaker.PowerOnWithVolume(context.Background(), volume, time.Second); err == nil {
+			t.Errorf("volume %d should be rejected", volume)
+		}
+	}
+}
+
+func TestAdjustVolume(t *testing.T) {
+	tests := []struct {
+		name   string
+		volume int
+		delta  int
+		want   int
+	}{
+		{"up", 30, 5, 35},
+		{"down", 30, -5, 25},
+		{"up to the max volume", 58, 5, 60},
+		{"down to 0", 3, -5, 0},
+		{"already at the max volume", 60, 5, 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeSpeaker(map[string]map[string]any{
+				"settings:/kef/host/maximumVolume": typedValue("i32_", 60),
+				"player:volume":                    typedValue("i32_", tt.volume),
+			})
+			speaker := newTestSpeaker(t, fake.ServeHTTP)
+
+			volume, err := speaker.AdjustVolume(tt.delta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if volume != tt.want {
+				t.Errorf("AdjustVolume(%d) returned %d, want %d", tt.delta, volume, tt.want)
+			}
+			current, err := speaker.GetVolume()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if current != tt.want {
+				t.Errorf("speaker volume is %d, want %d", current, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaybackControlNeedsControllableSource(t *testing.T) {
+	for source, wantErr := range map[Source]bool{SourceWiFi: false, SourceBluetooth: false, SourceTV: true} {
+		fake := newFakeSpeaker(map[string]map[string]any{
+			"settings:/kef/play/physicalSource": typedValue("kefPhysicalSource", string(source)),
+		})
+		speaker := newTestSpeaker(t, fake.ServeHTTP)
+
+		err := speaker.NextTrack()
+		if wantErr {
+			if !errors.Is(err, ErrSourceCannotControl) {
+				t.Errorf("NextTrack on %s: got %v, want ErrSourceCannotControl", source, err)
+			}
+			if want := "playback control unavailable on source '" + string(source) + "'"; err == nil || !strings.HasPrefix(err.Error(), want) {
+				t.Errorf("NextTrack on %s: got %v, want a message starting with %q", source, err, want)
+			}
+			if len(fake.sets) != 0 {
+				t.Errorf("NextTrack on %s sent %v", source, fake.sets)
+			}
+		} else if err != nil {
+			t.Errorf("NextTrack on %s: %v", source, err)
+		}
+	}
+}
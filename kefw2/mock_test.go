@@ -0,0 +1,87 @@
+package kefw2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newTestSpeaker returns a speaker that talks to a test server running handler
+func newTestSpeaker(t *testing.T, handler http.HandlerFunc, opts ...SpeakerOption) *KEFSpeaker {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	speaker := &KEFSpeaker{IPAddress: strings.TrimPrefix(server.URL, "http://")}
+	speaker.SetOptions(opts...)
+	return speaker
+}
+
+// fakeSpeaker answers getData and setData like a speaker, keeping the typed values by path
+type fakeSpeaker struct {
+	mu     sync.Mutex
+	values map[string]map[string]any
+	// sets logs every setData as "path=value" in the order received
+	sets []string
+}
+
+func newFakeSpeaker(values map[string]map[string]any) *fakeSpeaker {
+	return &fakeSpeaker{values: values}
+}
+
+// typedValue is a value as the speaker returns it, ie. {"type": "i32_", "i32_": 30}
+func typedValue(valueType string, value any) map[string]any {
+	return map[string]any{"type": valueType, valueType: value}
+}
+
+func (f *fakeSpeaker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.URL.Path {
+	case "/api/getData":
+		value, ok := f.values[r.URL.Query().Get("path")]
+		if !ok {
+			http.Error(w, "unknown path", http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode([]any{value})
+	case "/api/setData":
+		var req KEFPostRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var value map[string]any
+		json.Unmarshal(*req.Value, &value)
+		if req.Roles != "value" {
+			f.sets = append(f.sets, fmt.Sprintf("%s=%v", req.Path, value))
+			return
+		}
+		valueType, _ := value["type"].(string)
+		// Integers are sent as strings, but returned as numbers
+		if str, ok := value[valueType].(string); ok && valueType == "i32_" {
+			number, _ := strconv.Atoi(str)
+			value[valueType] = number
+		}
+		f.values[req.Path] = value
+		f.sets = append(f.sets, fmt.Sprintf("%s=%v", req.Path, value[valueType]))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// setIndex returns the position of the first set of "path=value", or -1
+func (f *fakeSpeaker) setIndex(set string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, s := range f.sets {
+		if s == set {
+			return i
+		}
+	}
+	return -1
+}
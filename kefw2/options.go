@@ -0,0 +1,30 @@
+package kefw2
+
+import "time"
+
+// SpeakerOption configures optional behaviour of a KEFSpeaker
+type SpeakerOption func(*KEFSpeaker)
+
+// WithTimeout sets how long to wait for the speaker to answer a request. The default is 1 second.
+func WithTimeout(timeout time.Duration) SpeakerOption {
+	return func(s *KEFSpeaker) {
+		s.timeout = timeout
+	}
+}
+
+// WithRetry makes failing requests to the speaker be tried up to attempts times in total.
+// The first retry waits backoff and the wait doubles for every following retry.
+// Only connection errors and 5xx responses are retried, and control actions are retried at most once.
+func WithRetry(attempts int, backoff time.Duration) SpeakerOption {
+	return func(s *KEFSpeaker) {
+		s.retryAttempts = attempts
+		s.retryBackoff = backoff
+	}
+}
+
+// SetOptions applies opts to the speaker, ie. to one read from a config file
+func (s *KEFSpeaker) SetOptions(opts ...SpeakerOption) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
@@ -1,8 +1,12 @@
 package kefw2
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"time"
 )
 
 type PlayerData struct {
@@ -71,9 +75,14 @@ type PlayerPlayID struct {
 }
 
 func (s *KEFSpeaker) PlayerData() (PlayerData, error) {
+	return s.playerData(context.Background())
+}
+
+// playerData is PlayerData, giving up when ctx is done
+func (s *KEFSpeaker) playerData(ctx context.Context) (PlayerData, error) {
 	var playersData []PlayerData
 	var err error
-	playersJson, err := s.getData("player:player/data")
+	playersJson, err := s.getDataContext(ctx, "player:player/data")
 	if err != nil {
 		return PlayerData{}, fmt.Errorf("error getting player data: %s", err)
 	}
@@ -82,12 +91,117 @@ func (s *KEFSpeaker) PlayerData() (PlayerData, error) {
 		// fmt.Printf("jsonData: %+v\n", string(playersJson))
 		return PlayerData{}, fmt.Errorf("error unmarshaling player data: %s", err)
 	}
+	if len(playersData) == 0 {
+		return PlayerData{}, fmt.Errorf("error getting player data: %w", errEmptyResponse)
+	}
 	playerData := playersData[0]
 	return playerData, nil
 }
 
+// WatchPlayerData polls the player data every interval and sends it on the returned channel
+// when the play state or the track changes. The first poll, and the first successful poll after an error, is always sent.
+// Errors, like the speaker going to standby, are sent on the error channel without stopping the polling.
+// If the error channel isn't read, errors are dropped. Both channels are closed when ctx is cancelled.
+func (s *KEFSpeaker) WatchPlayerData(ctx context.Context, interval time.Duration) (<-chan PlayerData, <-chan error) {
+	updates := make(chan PlayerData)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(updates)
+		defer close(errs)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var last PlayerData
+		// The first poll, and the first after an error, is always sent so the receiver knows it has recovered
+		resend := true
+		for {
+			pd, err := s.PlayerData()
+			if err != nil {
+				resend = true
+				select {
+				case errs <- err:
+				default:
+				}
+			} else if resend || pd.changedFrom(last) {
+				select {
+				case updates <- pd:
+				case <-ctx.Done():
+					return
+				}
+				last = pd
+				resend = false
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates, errs
+}
+
+// changedFrom reports if the play state or the playing track differs from other
+func (p PlayerData) changedFrom(other PlayerData) bool {
+	return p.State != other.State ||
+		p.TrackRoles.Title != other.TrackRoles.Title ||
+		p.TrackRoles.MediaData.MetaData != other.TrackRoles.MediaData.MetaData ||
+		p.MediaRoles.Title != other.MediaRoles.Title
+}
+
 // String returns the duration in minutes:seconds format instead of milliseconds
 func (p PlayerResource) String() string {
 	str := fmt.Sprintf("%d:%02d", p.Duration/60000, (p.Duration/1000)%60)
 	return str
 }
+
+// CurrentArtworkURL returns the absolute URL of the album art of the playing track.
+// Art served by the speaker itself, like embedded UPnP art, is resolved against the speaker address.
+func (s *KEFSpeaker) CurrentArtworkURL() (string, error) {
+	pd, err := s.PlayerData()
+	if err != nil {
+		return "", err
+	}
+	if pd.State != "playing" && pd.State != "paused" {
+		return "", errors.New("nothing is playing")
+	}
+	return s.ArtworkURL(pd)
+}
+
+// ArtworkURL returns the absolute URL of the album art in pd, see CurrentArtworkURL
+func (s *KEFSpeaker) ArtworkURL(pd PlayerData) (string, error) {
+	if pd.TrackRoles.Icon == "" {
+		return "", errors.New("no album art available for the current track")
+	}
+	artURL, err := url.Parse(pd.TrackRoles.Icon)
+	if err != nil {
+		return "", fmt.Errorf("invalid album art URL %q: %w", pd.TrackRoles.Icon, err)
+	}
+	base := &url.URL{Scheme: "http", Host: s.IPAddress, Path: "/"}
+	return base.ResolveReference(artURL).String(), nil
+}
+
+// CurrentStreamMetadata returns the title and artist of what is playing, see PlayerData.StreamMetadata
+func (s *KEFSpeaker) CurrentStreamMetadata() (title, artist string, err error) {
+	pd, err := s.PlayerData()
+	if err != nil {
+		return "", "", err
+	}
+	title, artist = pd.StreamMetadata()
+	return title, artist, nil
+}
+
+// StreamMetadata returns the title and artist of the playing track. For live streams, like radio stations,
+// that is the now playing info the station sends, falling back to the station name for stations that send none.
+func (p PlayerData) StreamMetadata() (title, artist string) {
+	title = p.TrackRoles.Title
+	artist = p.TrackRoles.MediaData.MetaData.Artist
+	if title == "" && p.IsLive() {
+		title = p.MediaRoles.Title
+	}
+	return title, artist
+}
+
+// IsLive reports if the playing media is a live stream, ie. a radio station
+func (p PlayerData) IsLive() bool {
+	return p.MediaRoles.MediaData.MetaData.Live
+}
@@ -0,0 +1,51 @@
+package kefw2
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchPlayerDataResendsAfterError(t *testing.T) {
+	var polls atomic.Int32
+	speaker := newTestSpeaker(t, func(w http.ResponseWriter, r *http.Request) {
+		// The second poll fails, the others return the same track
+		if polls.Add(1) == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`[{"state":"playing","trackRoles":{"title":"Track"}}]`))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	updates, errs := speaker.WatchPlayerData(ctx, 10*time.Millisecond)
+
+	expect := []string{"update", "error", "update"}
+	for _, want := range expect {
+		select {
+		case pd := <-updates:
+			if want != "update" {
+				t.Fatalf("got update %+v, want %s", pd, want)
+			}
+			if pd.TrackRoles.Title != "Track" {
+				t.Errorf("got title %q, want %q", pd.TrackRoles.Title, "Track")
+			}
+		case err := <-errs:
+			if want != "error" {
+				t.Fatalf("got error %v, want %s", err, want)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+
+	// Once recovered, the unchanged track isn't sent again
+	select {
+	case pd := <-updates:
+		t.Fatalf("got unexpected update %+v", pd)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -18,3 +18,8 @@ const (
 func (s *Source) String() string {
 	return string(*s)
 }
+
+// CanControlPlayback reports if playback can be controlled on the source, ie. play/pause, skip and seek
+func (s Source) CanControlPlayback() bool {
+	return s == SourceWiFi || s == SourceBluetooth
+}
@@ -0,0 +1,16 @@
+package kefw2
+
+// StandbyMode is the time of inactivity before the speaker goes to standby (kefStandbyMode)
+type StandbyMode string
+
+const (
+	StandbyMode20Mins StandbyMode = "standby_20mins"
+	StandbyMode30Mins StandbyMode = "standby_30mins"
+	StandbyMode60Mins StandbyMode = "standby_60mins"
+	StandbyModeNever  StandbyMode = "standby_none"
+)
+
+// String returns the string representation of the standby mode
+func (s *StandbyMode) String() string {
+	return string(*s)
+}
@@ -0,0 +1,95 @@
+package kefw2
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SubwooferSettings are the subwoofer output settings of the speaker.
+// Like the DSP settings they are stored in the EQ profile of the current source.
+type SubwooferSettings struct {
+	SubwooferOut      bool    `json:"subwooferOut"`
+	SubwooferCount    int     `json:"subwooferCount"`    // 0, 1, 2
+	SubwooferGain     int     `json:"subwooferGain"`     // dB, -10 to 10
+	SubOutLPFreq      float32 `json:"subOutLPFreq"`      // Crossover in Hz, 40 to 250
+	SubwooferPolarity string  `json:"subwooferPolarity"` // normal, inverted
+	SubEnableStereo   bool    `json:"subEnableStereo"`
+	HighPassMode      bool    `json:"highPassMode"`
+	HighPassModeFreq  int     `json:"highPassModeFreq"` // Hz, 50 to 120
+}
+
+var SubwooferPolarities = []string{"normal", "inverted"}
+
+// GetSubwooferSettings returns the subwoofer settings for the current source
+func (s *KEFSpeaker) GetSubwooferSettings() (SubwooferSettings, error) {
+	eqProfile, err := s.GetEQProfileV2()
+	if err != nil {
+		return SubwooferSettings{}, err
+	}
+	return SubwooferSettings{
+		SubwooferOut:      eqProfile.SubwooferOut,
+		SubwooferCount:    eqProfile.SubwooferCount,
+		SubwooferGain:     eqProfile.SubwooferGain,
+		SubOutLPFreq:      eqProfile.SubOutLPFreq,
+		SubwooferPolarity: eqProfile.SubwooferPolarity,
+		SubEnableStereo:   eqProfile.SubEnableStereo,
+		HighPassMode:      eqProfile.HighPassMode,
+		HighPassModeFreq:  eqProfile.HighPassModeFreq,
+	}, nil
+}
+
+// SetSubwooferSettings validates the subwoofer settings and writes them to the EQ profile of the current source.
+// The rest of the EQ profile is left untouched.
+func (s *KEFSpeaker) SetSubwooferSettings(sub SubwooferSettings) error {
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+	eqProfile, err := s.GetEQProfileV2()
+	if err != nil {
+		return fmt.Errorf("failed to get EQ profile: %w", err)
+	}
+	eqProfile.SubwooferOut = sub.SubwooferOut
+	eqProfile.SubwooferCount = sub.SubwooferCount
+	eqProfile.SubwooferGain = sub.SubwooferGain
+	eqProfile.SubOutLPFreq = sub.SubOutLPFreq
+	eqProfile.SubwooferPolarity = sub.SubwooferPolarity
+	eqProfile.SubEnableStereo = sub.SubEnableStereo
+	eqProfile.HighPassMode = sub.HighPassMode
+	eqProfile.HighPassModeFreq = sub.HighPassModeFreq
+	return s.SetEQProfileV2(eqProfile)
+}
+
+// Connected reports if a subwoofer is set up on the speaker.
+// The speaker can't sense a subwoofer, so this is based on the subwoofer output being enabled.
+func (sub SubwooferSettings) Connected() bool {
+	return sub.SubwooferOut && sub.SubwooferCount > 0
+}
+
+// Validate checks the subwoofer settings are within the ranges the speaker accepts.
+// The error names the field that is out of range.
+func (sub SubwooferSettings) Validate() error {
+	if sub.SubwooferCount < 0 || sub.SubwooferCount > 2 {
+		return fmt.Errorf("subwooferCount must be 0, 1 or 2, got %d", sub.SubwooferCount)
+	}
+	if sub.SubwooferGain < -10 || sub.SubwooferGain > 10 {
+		return fmt.Errorf("subwooferGain must be between -10 and 10 dB, got %d", sub.SubwooferGain)
+	}
+	if sub.SubOutLPFreq < 40 || sub.SubOutLPFreq > 250 {
+		return fmt.Errorf("subOutLPFreq (crossover) must be between 40 and 250 Hz, got %g", sub.SubOutLPFreq)
+	}
+	if sub.HighPassMode && (sub.HighPassModeFreq < 50 || sub.HighPassModeFreq > 120) {
+		return fmt.Errorf("highPassModeFreq must be between 50 and 120 Hz, got %d", sub.HighPassModeFreq)
+	}
+	for _, p := range SubwooferPolarities {
+		if sub.SubwooferPolarity == p {
+			return nil
+		}
+	}
+	return fmt.Errorf("subwooferPolarity must be one of: normal, inverted")
+}
+
+// String dumps a json SubwooferSettings
+func (sub SubwooferSettings) String() string {
+	settings, _ := json.MarshalIndent(sub, "", "  ")
+	return string(settings)
+}
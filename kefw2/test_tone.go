@@ -0,0 +1,68 @@
+package kefw2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Channel is the speaker of a stereo pair a test tone plays on
+type Channel string
+
+const (
+	ChannelLeft  Channel = "left"
+	ChannelRight Channel = "right"
+	ChannelBoth  Channel = "both"
+)
+
+var Channels = []Channel{ChannelLeft, ChannelRight, ChannelBoth}
+
+// ErrNoTestTone is returned by TestTone when the speaker has no way of playing on a single channel
+var ErrNoTestTone = errors.New("the speaker has no test tone or balance setting to identify the channels with")
+
+// TestTone plays on channel only for duration, to check the wiring of a stereo pair.
+// No test signal action is known in the speaker API, so the balance is turned all the way
+// to the channel instead and whatever is playing is heard there. The balance is restored
+// afterwards, also when ctx is cancelled.
+func (s *KEFSpeaker) TestTone(ctx context.Context, channel Channel, duration time.Duration) error {
+	balance, err := channelBalance(channel)
+	if err != nil {
+		return err
+	}
+	capabilities, err := s.Capabilities()
+	if err != nil {
+		return err
+	}
+	if !capabilities.DSP {
+		return ErrNoTestTone
+	}
+	original, err := s.GetBalance()
+	if err != nil {
+		return err
+	}
+	if err := s.SetBalance(balance); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(duration):
+	}
+	if err := s.SetBalance(original); err != nil {
+		return fmt.Errorf("failed to restore the balance to %d: %w", original, err)
+	}
+	return ctx.Err()
+}
+
+// channelBalance is the balance that plays on channel only
+func channelBalance(channel Channel) (int, error) {
+	switch channel {
+	case ChannelLeft:
+		return MinBalance, nil
+	case ChannelRight:
+		return MaxBalance, nil
+	case ChannelBoth:
+		return 0, nil
+	}
+	return 0, fmt.Errorf("channel must be one of: left, right, both")
+}
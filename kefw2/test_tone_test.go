@@ -0,0 +1,52 @@
+package kefw2
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTestToneLeft(t *testing.T) {
+	fake := newFakeSpeaker(map[string]map[string]any{
+		"settings:/kef/play/physicalSource": typedValue("kefPhysicalSource", string(SourceWiFi)),
+		"kef:eqProfile/v2":                  typedValue("kefEqProfileV2", map[string]any{"balance": 5}),
+	})
+	speaker := newTestSpeaker(t, fake.ServeHTTP)
+
+	if err := speaker.TestTone(context.Background(), ChannelLeft, 0); err != nil {
+		t.Fatal(err)
+	}
+	// Hard left, then back to where the balance was
+	want := []string{"balance:-30 ", "balance:5 "}
+	if len(fake.sets) != len(want) {
+		t.Fatalf("sets = %v, want %d balance changes", fake.sets, len(want))
+	}
+	for i, balance := range want {
+		if !strings.HasPrefix(fake.sets[i], "kef:eqProfile/v2=") || !strings.Contains(fake.sets[i], balance) {
+			t.Errorf("set %d = %q, want an EQ profile with %q", i, fake.sets[i], balance)
+		}
+	}
+}
+
+func TestTestToneWithoutBalance(t *testing.T) {
+	fake := newFakeSpeaker(map[string]map[string]any{
+		"settings:/kef/play/physicalSource": typedValue("kefPhysicalSource", string(SourceWiFi)),
+	})
+	speaker := newTestSpeaker(t, fake.ServeHTTP)
+
+	err := speaker.TestTone(context.Background(), ChannelLeft, 0)
+	if !errors.Is(err, ErrNoTestTone) {
+		t.Errorf("got %v, want ErrNoTestTone", err)
+	}
+	if len(fake.sets) != 0 {
+		t.Errorf("sets = %v, want none", fake.sets)
+	}
+}
+
+func TestTestToneInvalidChannel(t *testing.T) {
+	speaker := newTestSpeaker(t, newFakeSpeaker(nil).ServeHTTP)
+	if err := speaker.TestTone(context.Background(), "middle", 0); err == nil {
+		t.Error("expected an error for an unknown channel")
+	}
+}
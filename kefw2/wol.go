@@ -0,0 +1,38 @@
+package kefw2
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+)
+
+// WakeOnLAN sends a Wake-on-LAN magic packet for the speakers MAC address to the broadcast address.
+// This wakes up speakers in network standby, that doesn't answer HTTP requests.
+func (s KEFSpeaker) WakeOnLAN() error {
+	packet, err := magicPacket(s.MacAddress)
+	if err != nil {
+		return err
+	}
+	conn, err := net.Dial("udp", "255.255.255.255:9")
+	if err != nil {
+		return fmt.Errorf("failed to open broadcast connection: %w", err)
+	}
+	defer conn.Close()
+	_, err = conn.Write(packet)
+	return err
+}
+
+// magicPacket returns the Wake-on-LAN magic packet for a MAC address:
+// 6 bytes of 0xFF followed by the MAC address repeated 16 times.
+func magicPacket(macAddress string) ([]byte, error) {
+	mac, err := net.ParseMAC(macAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAC address %q: %w", macAddress, err)
+	}
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("invalid MAC address %q: Wake-on-LAN needs a 6 byte address", macAddress)
+	}
+	packet := bytes.Repeat([]byte{0xFF}, 6)
+	packet = append(packet, bytes.Repeat(mac, 16)...)
+	return packet, nil
+}
@@ -0,0 +1,35 @@
+package kefw2
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMagicPacket(t *testing.T) {
+	mac := []byte{0x84, 0x17, 0x15, 0x00, 0xAB, 0xCD}
+	for _, address := range []string{"84:17:15:00:ab:cd", "84-17-15-00-AB-CD"} {
+		packet, err := magicPacket(address)
+		if err != nil {
+			t.Fatalf("magicPacket(%q): %s", address, err)
+		}
+		if len(packet) != 102 {
+			t.Fatalf("magicPacket(%q) is %d bytes, want 102", address, len(packet))
+		}
+		if !bytes.Equal(packet[:6], bytes.Repeat([]byte{0xFF}, 6)) {
+			t.Errorf("magicPacket(%q) starts with % X, want 6 times FF", address, packet[:6])
+		}
+		for i := 0; i < 16; i++ {
+			if got := packet[6+i*6 : 12+i*6]; !bytes.Equal(got, mac) {
+				t.Errorf("magicPacket(%q) repetition %d is % X, want % X", address, i, got, mac)
+			}
+		}
+	}
+}
+
+func TestMagicPacketInvalidMAC(t *testing.T) {
+	for _, address := range []string{"", "not a mac", "84:17:15:00:ab", "84:17:15:00:ab:cd:ef:01"} {
+		if _, err := magicPacket(address); err == nil {
+			t.Errorf("magicPacket(%q) should fail", address)
+		}
+	}
+}